@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-github/v33/github"
+
+	"github.com/hellofresh/github-cli/pkg/config"
+	"github.com/hellofresh/github-cli/pkg/log"
+	"github.com/hellofresh/github-cli/pkg/repo"
+	"github.com/hellofresh/github-cli/pkg/test"
+)
+
+// newFakeContext starts the fake and returns a context running the commands against it with the configuration
+func newFakeContext(t *testing.T, fake *test.FakeGithub, cfg *config.Spec) context.Context {
+	t.Helper()
+
+	server := fake.Start()
+	t.Cleanup(server.Close)
+
+	ctx := log.NewContext(context.Background())
+	ctx = config.OverrideConfig(ctx, cfg)
+
+	return repo.NewContext(ctx, repo.NewGithub(fake.Client()))
+}
+
+// labelNames returns the names of the labels of a repository of the fake
+func labelNames(r *test.FakeRepo) []string {
+	var names []string
+	for _, label := range r.Labels {
+		names = append(names, label.GetName())
+	}
+
+	return names
+}
+
+// fakeLabels creates labels with a default color
+func fakeLabels(names ...string) []*github.Label {
+	var labels []*github.Label
+	for _, name := range names {
+		labels = append(labels, &github.Label{Name: github.String(name), Color: github.String("ededed")})
+	}
+
+	return labels
+}
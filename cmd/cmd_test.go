@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/hellofresh/github-cli/pkg/config"
+	"github.com/hellofresh/github-cli/pkg/log"
+	"github.com/hellofresh/github-cli/pkg/repo"
+	"github.com/hellofresh/github-cli/pkg/repo/repotest"
+)
+
+// newMockContext returns a context running the commands against the mocks with the configuration
+func newMockContext(mocks *repotest.Mocks, cfg *config.Spec) context.Context {
+	ctx := log.NewContext(context.Background())
+	ctx = config.OverrideConfig(ctx, cfg)
+
+	return repo.NewContext(ctx, mocks.GithubRepo())
+}
@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v33/github"
+	"github.com/spf13/cobra"
+
+	"github.com/hellofresh/github-cli/pkg/cache"
+	"github.com/hellofresh/github-cli/pkg/config"
+	gh "github.com/hellofresh/github-cli/pkg/github"
+	"github.com/hellofresh/github-cli/pkg/log"
+	"github.com/hellofresh/github-cli/pkg/repo"
+)
+
+// completionTTL is how long the suggestions read from GitHub are reused
+const completionTTL = 5 * time.Minute
+
+type (
+	// completeFunc suggests the values of an argument or a flag
+	completeFunc func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+	// nameLister lists the names suggested for an organization
+	nameLister func(ctx context.Context, creator *repo.GithubRepo, org string) ([]string, error)
+)
+
+// NewCompletionCmd creates a new completion command
+func NewCompletionCmd(ctx context.Context) *cobra.Command {
+	return &cobra.Command{
+		Use:   "completion bash|zsh|fish|powershell",
+		Short: "Generates the shell completion script",
+		Long: `Generates the completion script of the given shell. Repository names and team slugs are completed
+from GitHub and cached for a few minutes in $XDG_CACHE_HOME/github-cli/completion.
+
+  bash:       source <(github-cli completion bash)
+  zsh:        github-cli completion zsh > "${fpath[1]}/_github-cli"
+  fish:       github-cli completion fish > ~/.config/fish/completions/github-cli.fish
+  powershell: github-cli completion powershell | Out-String | Invoke-Expression`,
+		ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+		Args:                  cobra.ExactValidArgs(1),
+		DisableFlagsInUseLine: true,
+		Annotations:           map[string]string{skipConfigAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunCompletion(cmd.Root(), cmd.OutOrStdout(), args[0])
+		},
+	}
+}
+
+// RunCompletion writes the completion script of the shell for the root command
+func RunCompletion(root *cobra.Command, out io.Writer, shell string) error {
+	switch shell {
+	case "bash":
+		return root.GenBashCompletionV2(out, true)
+	case "zsh":
+		return root.GenZshCompletion(out)
+	case "fish":
+		return root.GenFishCompletion(out, true)
+	case "powershell":
+		return root.GenPowerShellCompletionWithDesc(out)
+	}
+
+	return fmt.Errorf("unsupported shell %q, expected bash, zsh, fish or powershell", shell)
+}
+
+// completeRepos completes the repositories of the configured organization
+func completeRepos(ctx context.Context, position int) completeFunc {
+	return completeNames(ctx, position, "repos", func(cfg *config.Spec) string { return cfg.Github.Organization }, listRepoNames)
+}
+
+// completeTestRepos completes the hiring test repositories of the test organization
+func completeTestRepos(ctx context.Context, position int) completeFunc {
+	return completeNames(ctx, position, "repos", func(cfg *config.Spec) string { return cfg.GithubTestOrg.Organization }, listRepoNames)
+}
+
+// completeTeams completes the team slugs of the configured organization
+func completeTeams(ctx context.Context) completeFunc {
+	return completeNames(ctx, -1, "teams", func(cfg *config.Spec) string { return cfg.Github.Organization }, listTeamSlugs)
+}
+
+// completeNames completes the argument at position, or a flag when position is negative, with the names
+// listed for the organization org selects. The names are cached for completionTTL.
+func completeNames(ctx context.Context, position int, kind string, org func(*config.Spec) string, list nameLister) completeFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if position >= 0 && len(args) != position {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		logger := log.WithContext(ctx)
+
+		cfg, err := completionConfig(ctx, cmd)
+		if err != nil {
+			logger.WithError(err).Debug("Could not read the configuration for completion")
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		owner := org(cfg)
+		if owner == "" {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		names, err := cachedNames(ctx, cfg, strings.Join([]string{kind, cfg.Github.BaseURL, owner}, " "), func(creator *repo.GithubRepo) ([]string, error) {
+			return list(ctx, creator, owner)
+		})
+		if err != nil {
+			logger.WithError(err).Debugf("Could not list %s for completion", kind)
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		var matches []string
+		for _, name := range names {
+			if strings.HasPrefix(name, toComplete) {
+				matches = append(matches, name)
+			}
+		}
+
+		return matches, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// cachedNames returns the names cached for key, or lists and caches them. The tokens are only
+// resolved when GitHub is called, they may run a command.
+func cachedNames(ctx context.Context, cfg *config.Spec, key string, list func(*repo.GithubRepo) ([]string, error)) ([]string, error) {
+	logger := log.WithContext(ctx)
+
+	var c *cache.Cache
+	if dir, err := config.CacheDir(); err == nil {
+		c = cache.New(filepath.Join(dir, "completion"), completionTTL)
+	}
+
+	var names []string
+	if c != nil && c.Get(key, &names) {
+		return names, nil
+	}
+
+	if err := cfg.ResolveTokens(ctx); err != nil {
+		return nil, err
+	}
+
+	client, err := gh.NewClient(ctx, cfg.Github.Token, cfg.Github.BaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if names, err = list(repo.NewGithub(client)); err != nil {
+		return nil, err
+	}
+
+	if c != nil {
+		if err := c.Set(key, names); err != nil {
+			logger.WithError(err).Debug("Could not cache the completion")
+		}
+	}
+
+	return names, nil
+}
+
+// completionConfig reads the configuration with the global flags of the completed command line, the root
+// command does not load it for completion as those flags are only parsed once the completion runs
+func completionConfig(ctx context.Context, cmd *cobra.Command) (*config.Spec, error) {
+	var opts RootOptions
+	opts.configFile, _ = cmd.Flags().GetString("config")
+	opts.context, _ = cmd.Flags().GetString("context")
+	opts.token, _ = cmd.Flags().GetString("token")
+	opts.org, _ = cmd.Flags().GetString("organization")
+
+	cfg, err := config.Read(ctx, opts.configFile)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyFlags(ctx, cfg, opts); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+func listRepoNames(ctx context.Context, creator *repo.GithubRepo, org string) ([]string, error) {
+	repos, err := creator.FetchAllRepos(ctx, org, 100, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(repos))
+	for _, r := range repos {
+		names = append(names, r.GetName())
+	}
+
+	return names, nil
+}
+
+func listTeamSlugs(ctx context.Context, creator *repo.GithubRepo, org string) ([]string, error) {
+	var slugs []string
+
+	opt := &github.ListOptions{PerPage: 100}
+	for {
+		teams, resp, err := creator.Teams.ListTeams(ctx, org, opt)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, team := range teams {
+			slugs = append(slugs, team.GetSlug())
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+
+	return slugs, nil
+}
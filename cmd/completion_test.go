@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gh "github.com/hellofresh/github-cli/pkg/github"
+	"github.com/hellofresh/github-cli/pkg/log"
+	"github.com/hellofresh/github-cli/pkg/test"
+)
+
+// completionFake starts a fake github serving the teams and repositories of an organization, listed across pages
+func completionFake(t *testing.T, org string, teams ...string) *test.FakeGithub {
+	t.Helper()
+
+	fake := test.NewFakeGithub()
+	fake.PerPage = 1
+	fake.AddOrg(org)
+	for _, team := range teams {
+		fake.AddTeam(org, team)
+	}
+
+	server := fake.Start()
+	t.Cleanup(server.Close)
+
+	return fake
+}
+
+// completionCmd returns a command with the global flags of the completed command line
+func completionCmd(t *testing.T, configFile string, flags ...string) *cobra.Command {
+	t.Helper()
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("config", "", "")
+	cmd.Flags().String("context", "", "")
+	cmd.Flags().String("token", "", "")
+	cmd.Flags().String("organization", "", "")
+	require.NoError(t, cmd.Flags().Parse(append([]string{"--config", configFile}, flags...)))
+
+	return cmd
+}
+
+// tokenCommand returns a token reference running a script, and a func counting how many times it ran
+func tokenCommand(t *testing.T) (string, func() int) {
+	t.Helper()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("the token command is a shell script")
+	}
+
+	dir := t.TempDir()
+	runs := filepath.Join(dir, "runs")
+	// commands are run without a shell
+	script := filepath.Join(dir, "token.sh")
+	require.NoError(t, ioutil.WriteFile(script, []byte("#!/bin/sh\necho run >> "+runs+"\necho ghp_cmd\n"), 0700))
+
+	return "cmd:" + script, func() int {
+		b, err := ioutil.ReadFile(runs)
+		if os.IsNotExist(err) {
+			return 0
+		}
+		require.NoError(t, err)
+
+		return strings.Count(string(b), "run\n")
+	}
+}
+
+func completionContext(t *testing.T) context.Context {
+	t.Helper()
+
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	ctx, err := gh.NewContext(log.NewContext(context.Background()), "")
+	require.NoError(t, err)
+
+	return ctx
+}
+
+func TestCompleteTeams(t *testing.T) {
+	ctx := completionContext(t)
+	token, runs := tokenCommand(t)
+	fake := completionFake(t, "hellofresh", "platform", "qa", "payments")
+	file := configFile(t, "config.toml", `[github]
+Organization = "hellofresh"
+BaseURL = "`+fake.URL+`/api/v3/"
+Token = "`+token+`"
+`)
+
+	names, directive := completeTeams(ctx)(completionCmd(t, file), nil, "")
+	assert.ElementsMatch(t, []string{"platform", "qa", "payments"}, names, "all the pages are listed")
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+	assert.Equal(t, 1, runs())
+	requests := len(fake.Requests())
+
+	names, _ = completeTeams(ctx)(completionCmd(t, file), nil, "p")
+	assert.ElementsMatch(t, []string{"platform", "payments"}, names)
+	assert.Equal(t, 1, runs(), "a cache hit does not resolve the token")
+	assert.Len(t, fake.Requests(), requests, "a cache hit does not call github")
+}
+
+func TestCompleteNamesCacheKey(t *testing.T) {
+	ctx := completionContext(t)
+	fake := completionFake(t, "hellofresh", "platform")
+	fake.AddOrg("hellofresh-oss")
+	fake.AddTeam("hellofresh-oss", "maintainers")
+	other := completionFake(t, "hellofresh", "security")
+
+	config := func(fake *test.FakeGithub) string {
+		return configFile(t, "config.toml", `[github]
+Organization = "hellofresh"
+BaseURL = "`+fake.URL+`/api/v3/"
+Token = "env:GHCLI_TEST_TOKEN"
+`)
+	}
+	t.Setenv("GHCLI_TEST_TOKEN", "ghp_token")
+	file := config(fake)
+
+	names, _ := completeTeams(ctx)(completionCmd(t, file), nil, "")
+	assert.Equal(t, []string{"platform"}, names)
+
+	names, _ = completeTeams(ctx)(completionCmd(t, file, "--organization", "hellofresh-oss"), nil, "")
+	assert.Equal(t, []string{"maintainers"}, names, "the names are cached by organization")
+
+	names, _ = completeTeams(ctx)(completionCmd(t, config(other)), nil, "")
+	assert.Equal(t, []string{"security"}, names, "the names are cached by base URL")
+
+	names, _ = completeTeams(ctx)(completionCmd(t, file), nil, "")
+	assert.Equal(t, []string{"platform"}, names)
+}
+
+func TestCompleteRepos(t *testing.T) {
+	ctx := completionContext(t)
+	fake := completionFake(t, "hellofresh")
+	for _, name := range []string{"svc-a", "svc-b", "website"} {
+		fake.AddRepo("hellofresh", name, false)
+	}
+	t.Setenv("GHCLI_TEST_TOKEN", "ghp_token")
+	file := configFile(t, "config.toml", `[github]
+Organization = "hellofresh"
+BaseURL = "`+fake.URL+`/api/v3/"
+Token = "env:GHCLI_TEST_TOKEN"
+`)
+
+	names, _ := completeRepos(ctx, 0)(completionCmd(t, file), nil, "svc")
+	assert.ElementsMatch(t, []string{"svc-a", "svc-b"}, names)
+
+	names, directive := completeRepos(ctx, 0)(completionCmd(t, file), []string{"svc-a"}, "")
+	assert.Empty(t, names, "only the argument at the position is completed")
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+}
+
+func TestCompleteNamesWithoutOrganization(t *testing.T) {
+	ctx := completionContext(t)
+	token, runs := tokenCommand(t)
+	fake := completionFake(t, "hellofresh", "platform")
+	file := configFile(t, "config.toml", `[github]
+BaseURL = "`+fake.URL+`/api/v3/"
+Token = "`+token+`"
+`)
+
+	names, directive := completeTeams(ctx)(completionCmd(t, file), nil, "")
+	assert.Empty(t, names)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+	assert.Zero(t, runs())
+	assert.Empty(t, fake.Requests())
+
+	names, _ = completeTestRepos(ctx, 0)(completionCmd(t, file), nil, "")
+	assert.Empty(t, names)
+}
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+)
+
+// NewConfigCmd aggregates the config commands
+func NewConfigCmd(ctx context.Context, rootOpts *RootOptions) *cobra.Command {
+	// Config commands
+	cmd := &cobra.Command{
+		Use:         "config",
+		Short:       "Configuration file management",
+		Annotations: map[string]string{skipConfigAnnotation: "true"},
+	}
+
+	cmd.AddCommand(NewConfigInitCmd(ctx, rootOpts))
+	cmd.AddCommand(NewConfigShowCmd(ctx, rootOpts))
+	cmd.AddCommand(NewConfigConvertCmd(ctx, rootOpts))
+	cmd.AddCommand(NewConfigValidateCmd(ctx, rootOpts))
+	cmd.AddCommand(NewConfigUseContextCmd(ctx, rootOpts))
+	cmd.AddCommand(NewConfigGetContextsCmd(ctx, rootOpts))
+	cmd.AddCommand(NewConfigSchemaCmd(ctx))
+
+	return cmd
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hellofresh/github-cli/pkg/config"
+	"github.com/hellofresh/github-cli/pkg/log"
+)
+
+// NewConfigUseContextCmd creates a new use-context command
+func NewConfigUseContextCmd(ctx context.Context, rootOpts *RootOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "use-context [name]",
+		Short: "Sets the default organization context",
+		Long:  `Sets CurrentContext in the configuration file, the context is used by all commands unless --context is given`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunConfigUseContext(ctx, args[0], rootOpts)
+		},
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 || args[0] == "" {
+				return errors.New("please provide a context name")
+			}
+
+			return nil
+		},
+	}
+}
+
+// RunConfigUseContext runs the command to set the default context
+func RunConfigUseContext(ctx context.Context, name string, rootOpts *RootOptions) error {
+	logger := log.WithContext(ctx)
+
+	cfg, err := config.Read(ctx, rootOpts.configFile)
+	if err != nil {
+		return err
+	}
+
+	if cfg.File() == "" {
+		return errors.New("no configuration file found")
+	}
+
+	if _, ok := cfg.Contexts[strings.ToLower(name)]; !ok {
+		return fmt.Errorf("context %q not found, available contexts: %s", name, strings.Join(cfg.ContextNames(), ", "))
+	}
+
+	if err := config.SetCurrentContext(cfg.File(), name); err != nil {
+		return err
+	}
+
+	logger.Infof("Switched to context %s", strings.ToLower(name))
+
+	return nil
+}
+
+// NewConfigGetContextsCmd creates a new get-contexts command
+func NewConfigGetContextsCmd(ctx context.Context, rootOpts *RootOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get-contexts",
+		Short: "Lists the organization contexts",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunConfigGetContexts(ctx, cmd, rootOpts)
+		},
+	}
+}
+
+// RunConfigGetContexts runs the command to list the contexts
+func RunConfigGetContexts(ctx context.Context, cmd *cobra.Command, rootOpts *RootOptions) error {
+	cfg, err := config.Read(ctx, rootOpts.configFile)
+	if err != nil {
+		return err
+	}
+
+	current := strings.ToLower(rootOpts.context)
+	if current == "" {
+		current = strings.ToLower(cfg.CurrentContext)
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CURRENT\tNAME\tORGANIZATION\tBASE URL")
+	for _, name := range cfg.ContextNames() {
+		marker := ""
+		if name == current {
+			marker = "*"
+		}
+
+		gh := cfg.Contexts[name]
+		if gh == nil {
+			gh = &config.Github{}
+		}
+
+		baseURL := gh.BaseURL
+		if baseURL == "" {
+			baseURL = "https://api.github.com/"
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", marker, name, gh.Organization, baseURL)
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/spf13/cobra"
+
+	"github.com/hellofresh/github-cli/pkg/config"
+	"github.com/hellofresh/github-cli/pkg/log"
+)
+
+// ConfigConvertOpts are the flags for the config convert command
+type ConfigConvertOpts struct {
+	Force bool
+}
+
+// NewConfigConvertCmd creates a new config convert command
+func NewConfigConvertCmd(ctx context.Context, rootOpts *RootOptions) *cobra.Command {
+	opts := &ConfigConvertOpts{}
+
+	cmd := &cobra.Command{
+		Use:   "convert [source] [target]",
+		Short: "Converts a configuration file to another format",
+		Long: `Converts a configuration file to the format of the target file extension: toml, yaml, yml or json.
+The source defaults to the file given with --config or the one found in the default locations.
+Comments are not carried over to the new file.`,
+		Example: "  github-cli config convert ~/.github.toml ~/.config/github-cli/config.yaml",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			source, target := rootOpts.configFile, args[len(args)-1]
+			if len(args) > 1 {
+				source = args[0]
+			}
+
+			return RunConfigConvert(ctx, source, target, opts)
+		},
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 || args[len(args)-1] == "" {
+				return errors.New("please provide the target file")
+			}
+
+			return cobra.MaximumNArgs(2)(cmd, args)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.Force, "force", "f", false, "Overwrite the target file if it exists")
+
+	return cmd
+}
+
+// RunConfigConvert runs the command to convert a configuration file
+func RunConfigConvert(ctx context.Context, source string, target string, opts *ConfigConvertOpts) error {
+	logger := log.WithContext(ctx)
+
+	if source == "" {
+		homeDir, err := homedir.Dir()
+		if err != nil {
+			return err
+		}
+
+		if source = config.Search(homeDir); source == "" {
+			return errors.New("no configuration file found, please provide one")
+		}
+	}
+
+	format, err := config.FormatOf(target)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(target); err == nil && !opts.Force {
+		return fmt.Errorf("%s already exists, use --force to overwrite it", target)
+	}
+
+	settings, err := config.ReadSettings(source)
+	if err != nil {
+		return err
+	}
+
+	b, err := config.MarshalSettings(settings, format)
+	if err != nil {
+		return fmt.Errorf("could not convert configuration: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+		return fmt.Errorf("could not create configuration directory: %w", err)
+	}
+
+	if err := ioutil.WriteFile(target, b, 0600); err != nil {
+		return fmt.Errorf("could not write configuration file: %w", err)
+	}
+
+	logger.Infof("Configuration %s converted to %s", source, target)
+
+	return nil
+}
@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hellofresh/github-cli/pkg/config"
+	gh "github.com/hellofresh/github-cli/pkg/github"
+	"github.com/hellofresh/github-cli/pkg/log"
+	"github.com/hellofresh/github-cli/pkg/test"
+)
+
+const contextsConfig = `CurrentContext = "main"
+
+[github]
+Organization = "hellofresh"
+Token = "env:GHCLI_TEST_TOKEN"
+Labels = [{Name = "type: bug", Color = "d73a4a"}]
+
+[contexts.main]
+Organization = "hellofresh"
+
+[contexts.oss]
+Organization = "hellofresh-oss"
+BaseURL = "https://github.example.com/api/v3/"
+`
+
+// configFile writes a configuration file for the config commands
+func configFile(t *testing.T, name string, content string) string {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), name)
+	require.NoError(t, ioutil.WriteFile(file, []byte(content), 0600))
+
+	return file
+}
+
+// configCmd returns a command writing its output to a buffer
+func configCmd() (*cobra.Command, *bytes.Buffer) {
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+
+	return cmd, &out
+}
+
+func TestConfigShowE2E(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GHCLI_TEST_TOKEN", "ghp_0123456789abcdefghij")
+	t.Setenv("GHCLI_GITHUB_SETTINGS_HOMEPAGE", "https://hellofresh.com")
+	file := configFile(t, "config.toml", contextsConfig)
+	ctx := log.NewContext(context.Background())
+
+	cmd, out := configCmd()
+	require.NoError(t, RunConfigShow(ctx, cmd, &RootOptions{configFile: file, context: "oss"}))
+
+	values := make(map[string][]string)
+	for _, line := range strings.Split(out.String(), "\n") {
+		if fields := strings.Fields(line); len(fields) == 3 {
+			values[fields[0]] = fields[1:]
+		}
+	}
+
+	assert.True(t, strings.HasPrefix(out.String(), "# "+file+"\n# context oss\n"))
+	assert.Equal(t, []string{`"hellofresh-oss"`, "file"}, values["github.organization"])
+	assert.Equal(t, []string{`"****ghij"`, "file"}, values["github.token"], "tokens are redacted")
+	assert.Equal(t, []string{`"https://hellofresh.com"`, "env"}, values["github.settings.homepage"])
+	assert.NotContains(t, values, "contexts")
+	assert.NotContains(t, out.String(), "ghp_0123456789abcdefghij")
+}
+
+func TestConfigValidateE2E(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GHCLI_TEST_TOKEN", "ghp_token")
+	ctx := log.NewContext(context.Background())
+
+	valid := configFile(t, "config.toml", contextsConfig)
+	assert.NoError(t, RunConfigValidate(ctx, valid, &RootOptions{}))
+
+	invalid := configFile(t, "config.yaml", `github:
+  Organization: hellofresh
+  Unknown: true
+  Teams:
+    - ID: 1
+      Permission: write
+update:
+  Channel: nightly
+`)
+	assert.EqualError(t, RunConfigValidate(ctx, invalid, &RootOptions{}), "found 3 problem(s) in "+invalid)
+
+	assert.EqualError(t, RunConfigValidate(ctx, valid, &RootOptions{context: "missing"}), `context "missing" not found, available contexts: main, oss`)
+}
+
+func TestConfigContextsE2E(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "")
+	file := configFile(t, "config.toml", contextsConfig)
+	ctx := log.NewContext(context.Background())
+
+	contexts := func(rootOpts *RootOptions) []string {
+		t.Helper()
+
+		cmd, out := configCmd()
+		require.NoError(t, RunConfigGetContexts(ctx, cmd, rootOpts))
+
+		var lines []string
+		for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+			lines = append(lines, strings.Join(strings.Fields(line), " "))
+		}
+		return lines
+	}
+
+	assert.Equal(t, []string{
+		"CURRENT NAME ORGANIZATION BASE URL",
+		"* main hellofresh https://api.github.com/",
+		"oss hellofresh-oss https://github.example.com/api/v3/",
+	}, contexts(&RootOptions{configFile: file}))
+	assert.Equal(t, "* oss hellofresh-oss https://github.example.com/api/v3/", contexts(&RootOptions{configFile: file, context: "OSS"})[2])
+
+	require.NoError(t, RunConfigUseContext(ctx, "oss", &RootOptions{configFile: file}))
+
+	spec, err := config.Read(ctx, file)
+	require.NoError(t, err)
+	assert.Equal(t, "oss", spec.CurrentContext)
+	assert.Equal(t, "* oss hellofresh-oss https://github.example.com/api/v3/", contexts(&RootOptions{configFile: file})[2])
+
+	b, err := ioutil.ReadFile(file)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `Token = "env:GHCLI_TEST_TOKEN"`, "the file is edited in place")
+
+	err = RunConfigUseContext(ctx, "missing", &RootOptions{configFile: file})
+	assert.EqualError(t, err, `context "missing" not found, available contexts: main, oss`)
+}
+
+func TestConfigConvertE2E(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "")
+	source := configFile(t, "config.toml", contextsConfig)
+	target := filepath.Join(t.TempDir(), "github-cli", "config.yaml")
+	ctx := log.NewContext(context.Background())
+
+	require.NoError(t, RunConfigConvert(ctx, source, target, &ConfigConvertOpts{}))
+
+	want, err := config.Read(ctx, source)
+	require.NoError(t, err)
+	got, err := config.Read(ctx, target)
+	require.NoError(t, err)
+	assert.Equal(t, want.Values(), got.Values())
+
+	err = RunConfigConvert(ctx, source, target, &ConfigConvertOpts{})
+	assert.EqualError(t, err, target+" already exists, use --force to overwrite it")
+	assert.NoError(t, RunConfigConvert(ctx, source, target, &ConfigConvertOpts{Force: true}))
+
+	err = RunConfigConvert(ctx, source, filepath.Join(t.TempDir(), "config.ini"), &ConfigConvertOpts{})
+	assert.Error(t, err)
+}
+
+func TestConfigSchemaE2E(t *testing.T) {
+	cmd := NewConfigSchemaCmd(context.Background())
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.Run(cmd, nil)
+
+	var schema map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &schema))
+	assert.Contains(t, schema, "properties")
+}
+
+func TestConfigInitE2E(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "")
+	fake := test.NewFakeGithub()
+	// the teams are listed across pages
+	fake.PerPage = 1
+	fake.AddOrg("hellofresh")
+	platformID := fake.AddTeam("hellofresh", "platform")
+	fake.AddTeam("hellofresh", "qa")
+	securityID := fake.AddTeam("hellofresh", "security")
+
+	ctx, err := gh.NewContext(newFakeContext(t, fake, &config.Spec{}), "")
+	require.NoError(t, err)
+
+	target := filepath.Join(t.TempDir(), "config.toml")
+	cmd, out := configCmd()
+	cmd.SetIn(strings.NewReader(strings.Join([]string{
+		"",         // organization, from --org
+		"1, 4",     // teams, out of range
+		"1,3",      // teams
+		"",         // permission of platform, push by default
+		"admin",    // permission of security
+		"y",        // proposed labels
+		"n",        // remove default labels
+		"hr-tests", // hiring organization
+	}, "\n") + "\n"))
+
+	rootOpts := &RootOptions{org: "hellofresh", token: "ghp_token"}
+	require.NoError(t, RunConfigInit(ctx, cmd, target, rootOpts, &ConfigInitOpts{}))
+
+	assert.Contains(t, out.String(), "[2] qa (qa)")
+	assert.Contains(t, out.String(), `"4" is not a number between 1 and 3`)
+
+	spec, err := config.Read(ctx, target)
+	require.NoError(t, err)
+	assert.Equal(t, "hellofresh", spec.Github.Organization)
+	assert.Equal(t, "ghp_token", spec.Github.Token)
+	assert.Equal(t, []*config.Team{{ID: int(platformID), Permission: "push"}, {ID: int(securityID), Permission: "admin"}}, spec.Github.Teams)
+	assert.Equal(t, proposedLabels, spec.Github.Labels)
+	assert.False(t, spec.Github.RemoveDefaultLabels)
+	assert.Equal(t, "hr-tests", spec.GithubTestOrg.Organization)
+
+	err = RunConfigInit(ctx, cmd, target, rootOpts, &ConfigInitOpts{})
+	assert.EqualError(t, err, target+" already exists, use --force to overwrite it")
+}
@@ -0,0 +1,324 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v33/github"
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/hellofresh/github-cli/pkg/config"
+	gh "github.com/hellofresh/github-cli/pkg/github"
+	"github.com/hellofresh/github-cli/pkg/log"
+)
+
+type (
+	// ConfigInitOpts are the flags for the config init command
+	ConfigInitOpts struct {
+		Force bool
+	}
+
+	// prompter asks questions on the terminal
+	prompter struct {
+		in  *bufio.Reader
+		out io.Writer
+	}
+)
+
+// proposedLabels are offered when creating a new configuration
+var proposedLabels = []*config.Label{
+	{Name: "Please CR", Color: "c2e0c6"},
+	{Name: "Do not merge", Color: "b60205"},
+}
+
+// NewConfigInitCmd creates a new config init command
+func NewConfigInitCmd(ctx context.Context, rootOpts *RootOptions) *cobra.Command {
+	opts := &ConfigInitOpts{}
+
+	cmd := &cobra.Command{
+		Use:   "init [file]",
+		Short: "Creates a configuration file interactively",
+		Long:  `Asks for your organization and token, lets you pick the teams and labels for new repositories and writes the configuration file. Defaults to $HOME/.github.toml`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			target := rootOpts.configFile
+			if len(args) > 0 {
+				target = args[0]
+			}
+
+			return RunConfigInit(ctx, cmd, target, rootOpts, opts)
+		},
+		Args: cobra.MaximumNArgs(1),
+	}
+
+	cmd.Flags().BoolVarP(&opts.Force, "force", "f", false, "Overwrite the configuration file if it exists")
+
+	return cmd
+}
+
+// RunConfigInit runs the command to create a configuration file
+func RunConfigInit(ctx context.Context, cmd *cobra.Command, target string, rootOpts *RootOptions, opts *ConfigInitOpts) error {
+	logger := log.WithContext(ctx)
+
+	if target == "" {
+		homeDir, err := homedir.Dir()
+		if err != nil {
+			return err
+		}
+
+		target = filepath.Join(homeDir, ".github.toml")
+	}
+
+	if _, err := os.Stat(target); err == nil && !opts.Force {
+		return fmt.Errorf("%s already exists, use --force to overwrite it", target)
+	}
+
+	p := &prompter{in: bufio.NewReader(cmd.InOrStdin()), out: cmd.OutOrStdout()}
+	cfg := &config.Spec{}
+
+	org, err := p.ask("GitHub organization", rootOpts.org)
+	if err != nil {
+		return err
+	}
+	if org == "" {
+		return errors.New("please provide an organization")
+	}
+	cfg.Github.Organization = org
+
+	token := rootOpts.token
+	if token == "" {
+		token = os.Getenv("GITHUB_TOKEN")
+	}
+	if token == "" {
+		fmt.Fprintln(p.out, "Create a token with `repo` access at https://github.com/settings/tokens/new")
+		if token, err = p.askSecret("GitHub token"); err != nil {
+			return err
+		}
+	}
+	if token == "" {
+		return errors.New("please provide a github token")
+	}
+	cfg.Github.Token = token
+
+	if err := gh.Authenticate(ctx, token, ""); err != nil {
+		return err
+	}
+
+	logger.Infof("Fetching teams of %s...", org)
+	teams, err := fetchAllTeams(ctx, org)
+	if err != nil {
+		return fmt.Errorf("could not retrieve teams: %w", err)
+	}
+
+	if cfg.Github.Teams, err = p.pickTeams(teams); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(p.out, "Proposed labels:")
+	for _, label := range proposedLabels {
+		fmt.Fprintf(p.out, "  %s (#%s)\n", label.Name, label.Color)
+	}
+	addLabels, err := p.confirm("Add these labels to new repositories?", true)
+	if err != nil {
+		return err
+	}
+	if addLabels {
+		cfg.Github.Labels = proposedLabels
+	}
+
+	if cfg.Github.RemoveDefaultLabels, err = p.confirm("Remove GitHub's default labels from new repositories?", true); err != nil {
+		return err
+	}
+
+	testOrg, err := p.ask("Organization for hiring tests (empty to skip)", "")
+	if err != nil {
+		return err
+	}
+	if testOrg != "" {
+		cfg.GithubTestOrg.Organization = testOrg
+		cfg.GithubTestOrg.Token = token
+	}
+
+	if err := cfg.Validate(); err != nil {
+		logValidationErrors(ctx, err)
+		return errors.New("the configuration is not valid")
+	}
+
+	if err := config.Write(target, cfg); err != nil {
+		return err
+	}
+
+	logger.Infof("Configuration written to %s", target)
+
+	return nil
+}
+
+func fetchAllTeams(ctx context.Context, org string) ([]*github.Team, error) {
+	var allTeams []*github.Team
+
+	creator, err := newGithubRepo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &github.ListOptions{PerPage: 100}
+	for {
+		teams, resp, err := creator.Teams.ListTeams(ctx, org, opt)
+		if err != nil {
+			return allTeams, err
+		}
+
+		allTeams = append(allTeams, teams...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+
+	return allTeams, nil
+}
+
+// pickTeams lets the user select teams and the permission each of them gets
+func (p *prompter) pickTeams(teams []*github.Team) ([]*config.Team, error) {
+	if len(teams) == 0 {
+		return nil, nil
+	}
+
+	fmt.Fprintln(p.out, "Teams:")
+	for i, team := range teams {
+		fmt.Fprintf(p.out, "  [%d] %s (%s)\n", i+1, team.GetName(), team.GetSlug())
+	}
+
+	for {
+		answer, err := p.ask("Teams with access to new repositories (comma separated numbers, empty for none)", "")
+		if err != nil {
+			return nil, err
+		}
+
+		picked, err := parseSelection(answer, len(teams))
+		if err != nil {
+			fmt.Fprintln(p.out, err)
+			continue
+		}
+
+		var result []*config.Team
+		for _, i := range picked {
+			team := teams[i]
+			permission, err := p.choose(fmt.Sprintf("Permission for %s", team.GetName()), []string{"pull", "triage", "push", "maintain", "admin"}, "push")
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, &config.Team{ID: int(team.GetID()), Permission: permission})
+		}
+
+		return result, nil
+	}
+}
+
+// parseSelection parses a comma separated list of 1-based indexes
+func parseSelection(answer string, max int) ([]int, error) {
+	var picked []int
+
+	for _, field := range strings.Split(answer, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		i, err := strconv.Atoi(field)
+		if err != nil || i < 1 || i > max {
+			return nil, fmt.Errorf("%q is not a number between 1 and %d", field, max)
+		}
+
+		picked = append(picked, i-1)
+	}
+
+	return picked, nil
+}
+
+// ask reads a line, returning the default value for an empty answer
+func (p *prompter) ask(question string, defaultValue string) (string, error) {
+	if defaultValue != "" {
+		fmt.Fprintf(p.out, "%s (%s): ", question, defaultValue)
+	} else {
+		fmt.Fprintf(p.out, "%s: ", question)
+	}
+
+	answer, err := p.in.ReadString('\n')
+	if err != nil && !(errors.Is(err, io.EOF) && answer != "") {
+		return "", fmt.Errorf("could not read answer: %w", err)
+	}
+
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return defaultValue, nil
+	}
+
+	return answer, nil
+}
+
+// askSecret reads a line without echoing it when running on a terminal
+func (p *prompter) askSecret(question string) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return p.ask(question, "")
+	}
+
+	fmt.Fprintf(p.out, "%s: ", question)
+	secret, err := term.ReadPassword(fd)
+	fmt.Fprintln(p.out)
+	if err != nil {
+		return "", fmt.Errorf("could not read answer: %w", err)
+	}
+
+	return strings.TrimSpace(string(secret)), nil
+}
+
+// confirm asks a yes/no question
+func (p *prompter) confirm(question string, defaultValue bool) (bool, error) {
+	hint := "y/N"
+	if defaultValue {
+		hint = "Y/n"
+	}
+
+	for {
+		answer, err := p.ask(fmt.Sprintf("%s [%s]", question, hint), "")
+		if err != nil {
+			return false, err
+		}
+
+		switch strings.ToLower(answer) {
+		case "":
+			return defaultValue, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+	}
+}
+
+// choose asks for one of the given options
+func (p *prompter) choose(question string, options []string, defaultValue string) (string, error) {
+	for {
+		answer, err := p.ask(fmt.Sprintf("%s [%s]", question, strings.Join(options, "/")), defaultValue)
+		if err != nil {
+			return "", err
+		}
+
+		for _, option := range options {
+			if answer == option {
+				return answer, nil
+			}
+		}
+	}
+}
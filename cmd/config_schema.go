@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hellofresh/github-cli/pkg/config"
+)
+
+// NewConfigSchemaCmd creates a new config schema command
+func NewConfigSchemaCmd(ctx context.Context) *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema",
+		Short: "Prints the JSON Schema of the configuration file",
+		Long:  `Prints the JSON Schema of the configuration file, editors can use it to validate and autocomplete your .github.toml`,
+		Run: func(cmd *cobra.Command, args []string) {
+			fmt.Fprint(cmd.OutOrStdout(), string(config.Schema))
+		},
+	}
+}
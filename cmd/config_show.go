@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hellofresh/github-cli/pkg/config"
+)
+
+// NewConfigShowCmd creates a new show config command
+func NewConfigShowCmd(ctx context.Context, rootOpts *RootOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "show",
+		Short: "Prints the effective configuration",
+		Long:  `Prints the effective configuration, after applying environment variables and flags, and where each value comes from. Tokens are redacted.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunConfigShow(ctx, cmd, rootOpts)
+		},
+	}
+}
+
+// RunConfigShow runs the command to print the effective configuration
+func RunConfigShow(ctx context.Context, cmd *cobra.Command, rootOpts *RootOptions) error {
+	cfg, err := config.Read(ctx, rootOpts.configFile)
+	if err != nil {
+		return err
+	}
+
+	if err := cfg.ResolveTokens(ctx); err != nil {
+		return err
+	}
+
+	if err := applyFlags(ctx, cfg, *rootOpts); err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "# %s\n", describeConfig(cfg))
+	if cfg.Context() != "" {
+		fmt.Fprintf(w, "# context %s\n", cfg.Context())
+	}
+	fmt.Fprintln(w, "KEY\tVALUE\tSOURCE")
+
+	for _, value := range cfg.Values() {
+		// the selected context is already shown as github, see config get-contexts for the others
+		if value.Key == "contexts" {
+			continue
+		}
+
+		b, err := json.Marshal(value.Redacted())
+		if err != nil {
+			return fmt.Errorf("could not format %s: %w", value.Key, err)
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\n", value.Key, b, value.Source)
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/spf13/cobra"
+
+	"github.com/hellofresh/github-cli/pkg/config"
+	"github.com/hellofresh/github-cli/pkg/log"
+)
+
+// NewConfigValidateCmd creates a new validate config command
+func NewConfigValidateCmd(ctx context.Context, rootOpts *RootOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate [file]",
+		Short: "Validates a configuration file",
+		Long:  `Validates a configuration file and reports all the problems found. Defaults to the file given with --config or $HOME/.github.toml`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configFile := rootOpts.configFile
+			if len(args) > 0 {
+				configFile = args[0]
+			}
+
+			return RunConfigValidate(ctx, configFile, rootOpts)
+		},
+		Args: cobra.MaximumNArgs(1),
+	}
+
+	return cmd
+}
+
+// RunConfigValidate runs the command to validate a configuration file
+func RunConfigValidate(ctx context.Context, configFile string, rootOpts *RootOptions) error {
+	logger := log.WithContext(ctx)
+
+	cfg, err := config.Read(ctx, configFile)
+	if err != nil {
+		return err
+	}
+
+	if err := cfg.ResolveTokens(ctx); err != nil {
+		return err
+	}
+
+	if err := applyFlags(ctx, cfg, *rootOpts); err != nil {
+		return err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		problems := logValidationErrors(ctx, err)
+		return fmt.Errorf("found %d problem(s) in %s", problems, describeConfig(cfg))
+	}
+
+	logger.Infof("Configuration in %s is valid", describeConfig(cfg))
+
+	return nil
+}
+
+// describeConfig names where the configuration was read from
+func describeConfig(cfg *config.Spec) string {
+	if cfg.File() == "" {
+		return "environment variables"
+	}
+
+	return cfg.File()
+}
+
+// logValidationErrors logs each of the problems reported by config.Spec.Validate and returns how many there are
+func logValidationErrors(ctx context.Context, err error) int {
+	logger := log.WithContext(ctx)
+
+	var merr *multierror.Error
+	if !errors.As(err, &merr) {
+		logger.Error(err.Error())
+		return 1
+	}
+
+	for _, e := range merr.Errors {
+		logger.Error(e.Error())
+	}
+
+	return len(merr.Errors)
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hellofresh/github-cli/pkg/config"
+	"github.com/hellofresh/github-cli/pkg/test"
+)
+
+// head returns the commit a branch of a repository of the fake points at
+func head(t *testing.T, r *test.FakeRepo, branch string) plumbing.Hash {
+	t.Helper()
+
+	clone, err := git.Clone(memory.NewStorage(), nil, &git.CloneOptions{
+		URL:           r.Data["clone_url"].(string),
+		ReferenceName: plumbing.NewBranchReferenceName(branch),
+	})
+	require.NoError(t, err)
+
+	ref, err := clone.Head()
+	require.NoError(t, err)
+
+	return ref.Hash()
+}
+
+func TestHiringSendE2E(t *testing.T) {
+	fake := test.NewFakeGithub()
+	fake.AddOrg("hellofresh-test")
+
+	// the clone urls of the repositories point at the started fake
+	ctx := newFakeContext(t, fake, &config.Spec{GithubTestOrg: config.Github{Organization: "hellofresh-test", Token: "test-token"}})
+	source := fake.AddRepo("hellofresh-test", "backend-test", true)
+
+	require.NoError(t, RunCreateTestRepo(ctx, "candidate", "backend-test", plumbing.NewBranchReferenceName("main")))
+
+	r := fake.Repo("hellofresh-test", "candidate-backend-test")
+	require.NotNil(t, r)
+	assert.Equal(t, true, r.Data["private"])
+	assert.Equal(t, false, r.Data["has_issues"])
+	require.Len(t, r.Invitations, 1)
+	assert.Equal(t, "candidate", r.Invitations[0].GetInvitee().GetLogin())
+	assert.Equal(t, "write", r.Invitations[0].GetPermissions())
+	assert.Equal(t, head(t, source, "main"), head(t, r, "main"), "the test is pushed to the candidate repository")
+}
+
+func TestHiringSendE2EMissingTest(t *testing.T) {
+	fake := test.NewFakeGithub()
+	fake.AddOrg("hellofresh-test")
+
+	ctx := newFakeContext(t, fake, &config.Spec{GithubTestOrg: config.Github{Organization: "hellofresh-test"}})
+
+	err := RunCreateTestRepo(ctx, "candidate", "backend-test", plumbing.NewBranchReferenceName("main"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "could not get hiring test repository")
+	assert.Nil(t, fake.Repo("hellofresh-test", "candidate-backend-test"))
+}
+
+func TestHiringUnseatE2E(t *testing.T) {
+	fake := test.NewFakeGithub()
+	fake.AddOrg("hellofresh-test")
+	fake.AddMember("hellofresh-test", "interviewer")
+
+	old := fake.AddRepo("hellofresh-test", "alice-backend-test", true)
+	old.Data["pushed_at"] = time.Now().Add(-10 * 7 * 24 * time.Hour).UTC().Format(time.RFC3339)
+	old.Collaborators["alice"] = "push"
+	old.Collaborators["interviewer"] = "admin"
+
+	recent := fake.AddRepo("hellofresh-test", "bob-backend-test", true)
+	recent.Collaborators["bob"] = "push"
+
+	ctx := newFakeContext(t, fake, &config.Spec{GithubTestOrg: config.Github{Organization: "hellofresh-test"}})
+
+	require.NoError(t, RunUnseat(ctx, &UnseatOpts{Page: 1, ReposPerPage: 50}))
+
+	assert.Equal(t, map[string]string{"interviewer": "admin"}, old.Collaborators, "outside collaborators of old tests are removed")
+	assert.Equal(t, map[string]string{"bob": "push"}, recent.Collaborators, "tests pushed to recently are left alone")
+}
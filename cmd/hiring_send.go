@@ -8,14 +8,13 @@ import (
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
 	"github.com/go-git/go-git/v5/storage/memory"
 	"github.com/google/go-github/v33/github"
 	"github.com/spf13/cobra"
 
 	"github.com/hellofresh/github-cli/pkg/config"
-	gh "github.com/hellofresh/github-cli/pkg/github"
 	"github.com/hellofresh/github-cli/pkg/log"
-	"github.com/hellofresh/github-cli/pkg/repo"
 )
 
 type (
@@ -47,6 +46,7 @@ func NewHiringSendCmd(ctx context.Context) *cobra.Command {
 
 			return nil
 		},
+		ValidArgsFunction: completeTestRepos(ctx, 1),
 	}
 
 	return cmd
@@ -58,9 +58,9 @@ func RunCreateTestRepo(ctx context.Context, candidate string, testRepo string, r
 
 	logger := log.WithContext(ctx)
 	cfg := config.WithContext(ctx)
-	githubClient := gh.WithContext(ctx)
-	if githubClient == nil {
-		return errors.New("failed to get github client")
+	creator, err := newGithubRepo(ctx)
+	if err != nil {
+		return err
 	}
 
 	org := cfg.GithubTestOrg.Organization
@@ -69,10 +69,16 @@ func RunCreateTestRepo(ctx context.Context, candidate string, testRepo string, r
 	}
 
 	target := fmt.Sprintf("%s-%s", candidate, testRepo)
+	log.AddField(ctx, log.FieldOrg, org)
+	log.AddField(ctx, log.FieldRepo, target)
+
+	source, _, err := creator.Repositories.Get(ctx, org, testRepo)
+	if err != nil {
+		return fmt.Errorf("could not get hiring test repository: %w", err)
+	}
 
-	creator := repo.NewGithub(githubClient)
 	logger.Infof("Creating repository %s/%s...", org, target)
-	_, err = creator.CreateRepo(ctx, org, &github.Repository{
+	created, err := creator.CreateRepo(ctx, org, &github.Repository{
 		Name:      github.String(target),
 		Private:   github.Bool(true),
 		HasIssues: github.Bool(false),
@@ -90,15 +96,20 @@ func RunCreateTestRepo(ctx context.Context, candidate string, testRepo string, r
 			Permission: "push",
 		},
 	}
-	err = creator.AddCollaborators(ctx, target, org, collaboratorsOpts)
+	results, err := creator.AddCollaborators(ctx, target, org, collaboratorsOpts)
+	logCollaborators(logger, results)
 	if err != nil {
 		return fmt.Errorf("could not add collaborators to repository: %w", err)
 	}
 
+	// the clone urls come from the API, so enterprise servers work as well
+	auth := &http.BasicAuth{Username: "github-cli", Password: cfg.GithubTestOrg.Token}
+
 	logger.Info("Cloning repository...")
 	r, err := git.Clone(memory.NewStorage(), nil, &git.CloneOptions{
 		Progress:      os.Stdout,
-		URL:           fmt.Sprintf("https://%s@github.com/%s/%s", cfg.GithubTestOrg.Token, org, testRepo),
+		URL:           source.GetCloneURL(),
+		Auth:          auth,
 		ReferenceName: reference,
 	})
 	if err != nil {
@@ -116,9 +127,10 @@ func RunCreateTestRepo(ctx context.Context, candidate string, testRepo string, r
 	logger.Debugf("Remote on %s/%s changed to %s", org, testRepo, git.DefaultRemoteName)
 
 	logger.Info("Pushing changes...")
-	remote.Config().URLs = []string{fmt.Sprintf("https://%s@github.com/%s/%s", cfg.GithubTestOrg.Token, org, target)}
+	remote.Config().URLs = []string{created.GetCloneURL()}
 	err = remote.Push(&git.PushOptions{
 		RemoteName: git.DefaultRemoteName,
+		Auth:       auth,
 		Progress:   os.Stdout,
 	})
 	if err != nil {
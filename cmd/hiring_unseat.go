@@ -12,7 +12,6 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/hellofresh/github-cli/pkg/config"
-	gh "github.com/hellofresh/github-cli/pkg/github"
 	"github.com/hellofresh/github-cli/pkg/log"
 )
 
@@ -54,18 +53,19 @@ func RunUnseat(ctx context.Context, opts *UnseatOpts) error {
 
 	logger := log.WithContext(ctx)
 	cfg := config.WithContext(ctx)
-	githubClient := gh.WithContext(ctx)
-	if githubClient == nil {
-		return errors.New("failed to get github client")
+	creator, err := newGithubRepo(ctx)
+	if err != nil {
+		return err
 	}
 
 	org := cfg.GithubTestOrg.Organization
 	if org == "" {
 		return errors.New("please provide an organization")
 	}
+	log.AddField(ctx, log.FieldOrg, org)
 
 	logger.Info("Fetching repositories...")
-	allRepos, err := fetchAllRepos(ctx, org, opts.ReposPerPage, opts.Page)
+	allRepos, err := creator.FetchAllRepos(ctx, org, opts.ReposPerPage, opts.Page)
 	if err != nil {
 		return fmt.Errorf("could not retrieve repositories: %w", err)
 	}
@@ -78,8 +78,8 @@ func RunUnseat(ctx context.Context, opts *UnseatOpts) error {
 		}
 
 		repoName := *repo.Name
-		logger.WithField("repo", repoName).Debug("Fetching outside collaborators")
-		outsideCollaborators, _, err := githubClient.Repositories.ListCollaborators(ctx, org, repoName, &github.ListCollaboratorsOptions{
+		logger.WithField(log.FieldRepo, repoName).Debug("Fetching outside collaborators")
+		outsideCollaborators, _, err := creator.Repositories.ListCollaborators(ctx, org, repoName, &github.ListCollaboratorsOptions{
 			Affiliation: "outside",
 		})
 		if err != nil {
@@ -88,10 +88,10 @@ func RunUnseat(ctx context.Context, opts *UnseatOpts) error {
 
 		for _, collaborator := range outsideCollaborators {
 			logger.WithFields(logrus.Fields{
-				"repo":         repoName,
+				log.FieldRepo:  repoName,
 				"collaborator": collaborator.GetLogin(),
 			}).Info("Deleting outside collaborators")
-			_, err := githubClient.Repositories.RemoveCollaborator(ctx, org, repoName, collaborator.GetLogin())
+			_, err := creator.Repositories.RemoveCollaborator(ctx, org, repoName, collaborator.GetLogin())
 			if err != nil {
 				return fmt.Errorf("could not unseat outside collaborator: %w", err)
 			}
@@ -104,36 +104,6 @@ func RunUnseat(ctx context.Context, opts *UnseatOpts) error {
 	return nil
 }
 
-func fetchAllRepos(ctx context.Context, owner string, reposPerPage int, page int) ([]*github.Repository, error) {
-	var allRepos []*github.Repository
-
-	logger := log.WithContext(ctx)
-	githubClient := gh.WithContext(ctx)
-	if githubClient == nil {
-		return nil, errors.New("failed to get github client")
-	}
-
-	opt := &github.RepositoryListByOrgOptions{
-		ListOptions: github.ListOptions{PerPage: reposPerPage, Page: page},
-	}
-
-	for {
-		logger.Debugf("Fetching repositories page [%d]", opt.Page)
-		repos, resp, err := githubClient.Repositories.ListByOrg(ctx, owner, opt)
-		if err != nil {
-			return allRepos, err
-		}
-
-		allRepos = append(allRepos, repos...)
-		if resp.NextPage == 0 {
-			break
-		}
-		opt.Page = resp.NextPage
-	}
-
-	return allRepos, nil
-}
-
 func isRepoInactive(repo *github.Repository) bool {
 	diff := time.Since(repo.PushedAt.Time)
 	weeksAgo := roundTime(diff.Seconds() / weekInSeconds)
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v33/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/hellofresh/github-cli/pkg/config"
+	"github.com/hellofresh/github-cli/pkg/repo/repotest"
+)
+
+// testRepo returns a hiring test repository last pushed to weeks ago
+func testRepo(name string, weeks int) *github.Repository {
+	return &github.Repository{
+		Name:     github.String(name),
+		PushedAt: &github.Timestamp{Time: time.Now().Add(-time.Duration(weeks) * 7 * 24 * time.Hour)},
+	}
+}
+
+func TestRunUnseat(t *testing.T) {
+	outside := mock.MatchedBy(func(opts *github.ListCollaboratorsOptions) bool { return opts.Affiliation == "outside" })
+
+	tests := []struct {
+		name   string
+		org    string
+		setup  func(m *repotest.Mocks)
+		errMsg string
+	}{
+		{
+			name: "removes the outside collaborators of old tests",
+			org:  "hellofresh-test",
+			setup: func(m *repotest.Mocks) {
+				m.Repositories.On("ListByOrg", mock.Anything, "hellofresh-test", mock.Anything).
+					Return([]*github.Repository{testRepo("alice-backend-test", 10), testRepo("bob-backend-test", 1)}, &github.Response{}, nil)
+				m.Repositories.On("ListCollaborators", mock.Anything, "hellofresh-test", "alice-backend-test", outside).
+					Return([]*github.User{{Login: github.String("alice")}, {Login: github.String("carol")}}, &github.Response{}, nil)
+				m.Repositories.On("RemoveCollaborator", mock.Anything, "hellofresh-test", "alice-backend-test", "alice").Return(&github.Response{}, nil)
+				m.Repositories.On("RemoveCollaborator", mock.Anything, "hellofresh-test", "alice-backend-test", "carol").Return(&github.Response{}, nil)
+			},
+		},
+		{
+			name: "repositories can not be listed",
+			org:  "hellofresh-test",
+			setup: func(m *repotest.Mocks) {
+				m.Repositories.On("ListByOrg", mock.Anything, "hellofresh-test", mock.Anything).Return(nil, nil, errors.New("401 Bad credentials"))
+			},
+			errMsg: "could not retrieve repositories: 401 Bad credentials",
+		},
+		{
+			name: "collaborator can not be removed",
+			org:  "hellofresh-test",
+			setup: func(m *repotest.Mocks) {
+				m.Repositories.On("ListByOrg", mock.Anything, "hellofresh-test", mock.Anything).
+					Return([]*github.Repository{testRepo("alice-backend-test", 10)}, &github.Response{}, nil)
+				m.Repositories.On("ListCollaborators", mock.Anything, "hellofresh-test", "alice-backend-test", outside).
+					Return([]*github.User{{Login: github.String("alice")}}, &github.Response{}, nil)
+				m.Repositories.On("RemoveCollaborator", mock.Anything, "hellofresh-test", "alice-backend-test", "alice").Return(nil, errors.New("403 Forbidden"))
+			},
+			errMsg: "could not unseat outside collaborator: 403 Forbidden",
+		},
+		{
+			name:   "missing organization",
+			setup:  func(m *repotest.Mocks) {},
+			errMsg: "please provide an organization",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocks := repotest.NewMocks()
+			tt.setup(mocks)
+			ctx := newMockContext(mocks, &config.Spec{GithubTestOrg: config.Github{Organization: tt.org}})
+
+			err := RunUnseat(ctx, &UnseatOpts{Page: 1, ReposPerPage: 50})
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.errMsg)
+			}
+
+			mocks.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+)
+
+// NewLabelsCmd aggregates the labels commands
+func NewLabelsCmd(ctx context.Context) *cobra.Command {
+	// Labels commands
+	cmd := &cobra.Command{
+		Use:     "labels",
+		Aliases: []string{"label"},
+		Short:   "Github repository labels management",
+	}
+
+	cmd.AddCommand(NewLabelsSyncCmd(ctx))
+
+	return cmd
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hellofresh/github-cli/pkg/config"
+	"github.com/hellofresh/github-cli/pkg/log"
+	"github.com/hellofresh/github-cli/pkg/repo"
+)
+
+// LabelsSyncOpts are the flags for the labels sync command
+type LabelsSyncOpts struct {
+	All           bool
+	RemoveUnknown bool
+	DryRun        bool
+}
+
+// NewLabelsSyncCmd creates a new labels sync command
+func NewLabelsSyncCmd(ctx context.Context) *cobra.Command {
+	opts := &LabelsSyncOpts{}
+
+	cmd := &cobra.Command{
+		Use:   "sync [repo]",
+		Short: "Makes the labels of a repository match the configured ones",
+		Long: `Creates the configured labels and updates their color and description. Labels named after one
+of the aliases of a configured label are renamed, keeping their issues and pull requests, or merged into it
+when it already exists. The changes are printed before they are made.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			repoName := ""
+			if len(args) > 0 {
+				repoName = args[0]
+			}
+			return RunLabelsSync(ctx, cmd, repoName, opts)
+		},
+		Args: func(cmd *cobra.Command, args []string) error {
+			if opts.All && len(args) > 0 {
+				return errors.New("please provide either a repository name or --all")
+			}
+			if !opts.All && (len(args) < 1 || args[0] == "") {
+				return errors.New("please provide a repository name or --all")
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.All, "all", false, "Syncs the labels of all the repositories of the organization")
+	cmd.Flags().BoolVar(&opts.RemoveUnknown, "remove-unknown", false, "Deletes all the labels that are not configured, not only GitHub's default ones")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Only prints the changes")
+
+	return cmd
+}
+
+// RunLabelsSync runs the command to sync the labels of one or all repositories
+func RunLabelsSync(ctx context.Context, cmd *cobra.Command, repoName string, opts *LabelsSyncOpts) error {
+	if repoName != "" {
+		log.AddField(ctx, log.FieldRepo, repoName)
+	}
+	logger := log.WithContext(ctx)
+	cfg := config.WithContext(ctx)
+
+	creator, org, err := newOrgRepo(ctx)
+	if err != nil {
+		return err
+	}
+
+	labelsOpts := &repo.LabelsOpts{
+		Labels:              cfg.Github.Labels,
+		RemoveDefaultLabels: cfg.Github.RemoveDefaultLabels,
+		RemoveUnknown:       opts.RemoveUnknown,
+	}
+
+	repoNames := []string{repoName}
+	if opts.All {
+		logger.Info("Fetching repositories...")
+		allRepos, err := creator.FetchAllRepos(ctx, org, 100, 1)
+		if err != nil {
+			return fmt.Errorf("could not retrieve repositories: %w", err)
+		}
+
+		repoNames = nil
+		for _, r := range allRepos {
+			if !r.GetArchived() {
+				repoNames = append(repoNames, r.GetName())
+			}
+		}
+	}
+
+	out := cmd.OutOrStdout()
+	for _, name := range repoNames {
+		current, err := creator.ListLabels(ctx, name, org)
+		if err != nil {
+			return fmt.Errorf("could not retrieve labels of %s: %w", name, err)
+		}
+
+		changes := repo.PlanLabels(current, labelsOpts)
+		if len(changes) == 0 {
+			logger.Debugf("Labels of %s/%s are up to date", org, name)
+			continue
+		}
+
+		fmt.Fprintf(out, "%s/%s\n", org, name)
+		for _, change := range changes {
+			fmt.Fprintf(out, "  %s\n", change)
+		}
+
+		if opts.DryRun {
+			continue
+		}
+
+		if err := creator.ApplyLabelChanges(ctx, name, org, changes); err != nil {
+			return fmt.Errorf("could not sync labels of %s: %w", name, err)
+		}
+	}
+
+	if opts.DryRun {
+		logger.Info("Dry run, no labels were changed")
+	} else {
+		logger.Info("Labels synced!")
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hellofresh/github-cli/pkg/config"
+	"github.com/hellofresh/github-cli/pkg/test"
+)
+
+// labelsConfig are the configured labels of the labels sync tests
+func labelsConfig() *config.Spec {
+	return &config.Spec{Github: config.Github{
+		Organization: "hellofresh",
+		Labels: []*config.Label{
+			{Name: "type: bug", Color: "d73a4a", Aliases: []string{"bug"}},
+			{Name: "needs-review", Color: "ededed", Aliases: []string{"needs review"}},
+		},
+		RemoveDefaultLabels: true,
+	}}
+}
+
+func TestLabelsSyncE2E(t *testing.T) {
+	fake := test.NewFakeGithub()
+	// the labels and the issues of the aliases are found across pages
+	fake.PerPage = 1
+	fake.AddOrg("hellofresh")
+
+	ctx := newFakeContext(t, fake, labelsConfig())
+	r := fake.AddRepo("hellofresh", "svc", true)
+	r.Labels = fakeLabels("bug", "wontfix", "needs-review", "needs review", "team-x-priority")
+	r.Issues = map[int][]string{1: {"needs review"}, 2: {"bug"}, 3: {"needs review", "team-x-priority"}}
+
+	cmd := NewLabelsSyncCmd(ctx)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	require.NoError(t, RunLabelsSync(ctx, cmd, "svc", &LabelsSyncOpts{}))
+
+	assert.Contains(t, out.String(), "hellofresh/svc\n")
+	assert.ElementsMatch(t, []string{"type: bug", "needs-review", "team-x-priority"}, labelNames(r))
+	assert.Equal(t, map[int][]string{1: {"needs-review"}, 2: {"type: bug"}, 3: {"team-x-priority", "needs-review"}}, r.Issues)
+
+	out.Reset()
+	require.NoError(t, RunLabelsSync(ctx, cmd, "svc", &LabelsSyncOpts{}))
+	assert.Empty(t, out.String(), "synced labels have no changes")
+
+	require.NoError(t, RunLabelsSync(ctx, cmd, "svc", &LabelsSyncOpts{RemoveUnknown: true}))
+	assert.ElementsMatch(t, []string{"type: bug", "needs-review"}, labelNames(r))
+}
+
+func TestLabelsSyncE2EDryRun(t *testing.T) {
+	fake := test.NewFakeGithub()
+	fake.AddOrg("hellofresh")
+
+	ctx := newFakeContext(t, fake, labelsConfig())
+	r := fake.AddRepo("hellofresh", "svc", true)
+	r.Labels = fakeLabels("bug", "wontfix")
+
+	cmd := NewLabelsSyncCmd(ctx)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	require.NoError(t, RunLabelsSync(ctx, cmd, "svc", &LabelsSyncOpts{DryRun: true}))
+
+	assert.Contains(t, out.String(), "hellofresh/svc\n")
+	assert.Equal(t, []string{"bug", "wontfix"}, labelNames(r))
+	for _, request := range fake.Requests() {
+		assert.Regexp(t, "^GET ", request)
+	}
+}
+
+func TestLabelsSyncE2EAll(t *testing.T) {
+	fake := test.NewFakeGithub()
+	// the repositories are found across pages
+	fake.PerPage = 1
+	fake.AddOrg("hellofresh")
+
+	ctx := newFakeContext(t, fake, labelsConfig())
+	api := fake.AddRepo("hellofresh", "api", true)
+	api.Labels = fakeLabels("bug")
+	web := fake.AddRepo("hellofresh", "web", true)
+	archived := fake.AddRepo("hellofresh", "legacy", true)
+	archived.Data["archived"] = true
+	archived.Labels = fakeLabels("bug")
+
+	cmd := NewLabelsSyncCmd(ctx)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	require.NoError(t, RunLabelsSync(ctx, cmd, "", &LabelsSyncOpts{All: true}))
+
+	assert.Equal(t, []string{"type: bug", "needs-review"}, labelNames(api))
+	assert.Equal(t, []string{"type: bug", "needs-review"}, labelNames(web))
+	assert.Equal(t, []string{"bug"}, labelNames(archived), "archived repositories are skipped")
+	assert.NotContains(t, out.String(), "hellofresh/legacy")
+}
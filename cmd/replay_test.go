@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"context"
+	"flag"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v33/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/hellofresh/github-cli/internal/fixture"
+	"github.com/hellofresh/github-cli/pkg/config"
+	"github.com/hellofresh/github-cli/pkg/log"
+	"github.com/hellofresh/github-cli/pkg/repo"
+	"github.com/hellofresh/github-cli/pkg/test"
+)
+
+// record records the fixtures again against the fake github, run with go test ./cmd -run Replay -record
+var record = flag.Bool("record", false, "record the fixtures against the fake github instead of replaying them")
+
+const (
+	fixtureToken        = "ghp_fixturetoken"
+	fixtureTestOrgToken = "ghp_fixturetestorgtoken"
+	fixtureHookSecret   = "fixture-webhook-secret"
+)
+
+// replayContext returns a context running the commands against the interactions of the fixture name. With
+// -record the interactions are recorded against the fake github seeded by seed instead. The fixture must
+// be replayed entirely and must not hold any of the secrets.
+func replayContext(t *testing.T, name string, cfg *config.Spec, seed func(fake *test.FakeGithub)) context.Context {
+	t.Helper()
+
+	file := filepath.Join("testdata", "fixtures", name+".json")
+	cfg.Github.Token = fixtureToken
+	cfg.GithubTestOrg.Token = fixtureTestOrgToken
+	t.Setenv("FIXTURE_WEBHOOK_SECRET", fixtureHookSecret)
+
+	var client *github.Client
+	if *record {
+		fake := test.NewFakeGithub()
+		server := fake.Start()
+		t.Cleanup(server.Close)
+		seed(fake)
+
+		transport := &oauth2.Transport{Source: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: fixtureToken})}
+		client = github.NewClient(&http.Client{
+			Transport: fixture.NewRecorder(file).Wrap(transport, fixtureToken, fixtureTestOrgToken),
+		})
+		client.BaseURL, _ = url.Parse(fake.URL + "/")
+	} else {
+		interactions, err := fixture.Load(file)
+		require.NoError(t, err)
+
+		replayer := fixture.NewReplayer(interactions)
+		client = github.NewClient(&http.Client{Transport: replayer})
+		t.Cleanup(func() {
+			assert.Empty(t, replayer.Missing(), "requests that were not recorded")
+			assert.Empty(t, replayer.Unused(), "recorded requests that were not replayed")
+		})
+	}
+
+	t.Cleanup(func() {
+		b, err := ioutil.ReadFile(file)
+		require.NoError(t, err)
+		for _, secret := range []string{fixtureToken, fixtureTestOrgToken, fixtureHookSecret} {
+			assert.NotContains(t, string(b), secret, "secrets must be scrubbed from the fixtures")
+		}
+	})
+
+	ctx := log.NewContext(context.Background())
+	ctx = config.OverrideConfig(ctx, cfg)
+
+	return repo.NewContext(ctx, repo.NewGithub(client))
+}
+
+func TestReplayRepoCreate(t *testing.T) {
+	ctx := replayContext(t, "repo_create", &config.Spec{Github: config.Github{
+		Organization:  "hellofresh",
+		Collaborators: []*config.Collaborator{{Username: "alice", Permission: "pull"}},
+		Labels:        []*config.Label{{Name: "type: bug", Color: "d73a4a"}},
+		Webhooks: []*config.Webhook{{
+			Type:   "web",
+			Config: map[string]interface{}{"url": "https://ci.example.com/hook", "content_type": "json", "secret": "env:FIXTURE_WEBHOOK_SECRET"},
+			Events: []string{"push"},
+		}},
+		Protections: config.BranchProtections{"main": {"ci/build"}},
+		Settings:    config.RepositorySettings{DefaultBranch: "develop"},
+	}}, func(fake *test.FakeGithub) {
+		fake.AddOrg("hellofresh")
+	})
+
+	opts := createOpts()
+	opts.HasWebhooks = true
+	require.NoError(t, RunCreateRepo(ctx, "svc", opts))
+}
+
+func TestReplayRepoUpdate(t *testing.T) {
+	ctx := replayContext(t, "repo_update", &config.Spec{Github: config.Github{
+		Organization: "hellofresh",
+		Settings:     config.RepositorySettings{Homepage: "https://hellofresh.com"},
+	}}, func(fake *test.FakeGithub) {
+		fake.AddOrg("hellofresh")
+		fake.AddRepo("hellofresh", "svc", true)
+	})
+
+	require.NoError(t, RunUpdateRepo(ctx, "svc", &UpdateRepoOptions{
+		Description: github.String("New description"),
+		Archived:    github.Bool(true),
+		ApplyConfig: true,
+	}))
+}
+
+func TestReplayRepoDelete(t *testing.T) {
+	ctx := replayContext(t, "repo_delete", &config.Spec{Github: config.Github{Organization: "hellofresh"}}, func(fake *test.FakeGithub) {
+		fake.AddOrg("hellofresh")
+		fake.AddRepo("hellofresh", "svc", true)
+	})
+
+	require.NoError(t, RunDeleteRepo(ctx, "svc", &DeleteRepoOpts{}))
+}
+
+func TestReplayWebhookAdd(t *testing.T) {
+	ctx := replayContext(t, "webhook_add", &config.Spec{Github: config.Github{Organization: "hellofresh"}}, func(fake *test.FakeGithub) {
+		fake.AddOrg("hellofresh")
+		fake.AddRepo("hellofresh", "svc", true)
+	})
+
+	require.NoError(t, RunWebhookAdd(ctx, "svc", &WebhookAddOpts{
+		URL:         "https://ci.example.com/hook",
+		ContentType: "json",
+		Secret:      "env:FIXTURE_WEBHOOK_SECRET",
+		Events:      []string{"push", "pull_request"},
+	}))
+}
+
+func TestReplayLabelsSync(t *testing.T) {
+	ctx := replayContext(t, "labels_sync", &config.Spec{Github: config.Github{
+		Organization: "hellofresh",
+		Labels: []*config.Label{
+			{Name: "type: bug", Color: "d73a4a", Aliases: []string{"bug"}},
+			{Name: "needs-review", Color: "ededed", Aliases: []string{"needs review"}},
+		},
+		RemoveDefaultLabels: true,
+	}}, func(fake *test.FakeGithub) {
+		fake.AddOrg("hellofresh")
+		r := fake.AddRepo("hellofresh", "svc", true)
+		r.Labels = fakeLabels("bug", "wontfix", "needs-review", "needs review", "team-x-priority")
+		r.Issues = map[int][]string{1: {"needs review"}, 2: {"bug"}}
+	})
+
+	cmd := NewLabelsSyncCmd(ctx)
+	cmd.SetOut(ioutil.Discard)
+	require.NoError(t, RunLabelsSync(ctx, cmd, "svc", &LabelsSyncOpts{}))
+}
+
+func TestReplayHiringUnseat(t *testing.T) {
+	ctx := replayContext(t, "hiring_unseat", &config.Spec{GithubTestOrg: config.Github{Organization: "hellofresh-test"}}, func(fake *test.FakeGithub) {
+		fake.AddOrg("hellofresh-test")
+		r := fake.AddRepo("hellofresh-test", "alice-backend-test", true)
+		r.Data["pushed_at"] = time.Now().Add(-10 * 7 * 24 * time.Hour).UTC().Format(time.RFC3339)
+		r.Collaborators["alice"] = "push"
+	})
+
+	require.NoError(t, RunUnseat(ctx, &UnseatOpts{Page: 1, ReposPerPage: 50}))
+}
@@ -2,8 +2,13 @@ package cmd
 
 import (
 	"context"
+	"errors"
 
 	"github.com/spf13/cobra"
+
+	"github.com/hellofresh/github-cli/pkg/config"
+	gh "github.com/hellofresh/github-cli/pkg/github"
+	"github.com/hellofresh/github-cli/pkg/repo"
 )
 
 // NewRepoCmd aggregates the repo comamnds
@@ -16,6 +21,41 @@ func NewRepoCmd(ctx context.Context) *cobra.Command {
 
 	cmd.AddCommand(NewCreateRepoCmd(ctx))
 	cmd.AddCommand(NewDeleteRepoCmd(ctx))
+	cmd.AddCommand(NewListReposCmd(ctx))
+	cmd.AddCommand(NewShowRepoCmd(ctx))
+	cmd.AddCommand(NewUpdateRepoCmd(ctx))
+	cmd.AddCommand(NewRenameRepoCmd(ctx))
+	cmd.AddCommand(NewTransferRepoCmd(ctx))
 
 	return cmd
 }
+
+// newGithubRepo returns the github repository client of the context, or one using the github client of the context
+func newGithubRepo(ctx context.Context) (*repo.GithubRepo, error) {
+	if r := repo.WithContext(ctx); r != nil {
+		return r, nil
+	}
+
+	githubClient := gh.WithContext(ctx)
+	if githubClient == nil {
+		return nil, errors.New("failed to get github client")
+	}
+
+	return repo.NewGithub(githubClient), nil
+}
+
+// newOrgRepo returns the github repository client and the configured organization
+func newOrgRepo(ctx context.Context) (*repo.GithubRepo, string, error) {
+	cfg := config.WithContext(ctx)
+	creator, err := newGithubRepo(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	org := cfg.Github.Organization
+	if org == "" {
+		return nil, "", errors.New("please provide an organization")
+	}
+
+	return creator, org, nil
+}
@@ -4,16 +4,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"strconv"
+	"time"
 
 	"github.com/google/go-github/v33/github"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
-	"golang.org/x/sync/errgroup"
 
 	"github.com/hellofresh/github-cli/pkg/config"
-	gh "github.com/hellofresh/github-cli/pkg/github"
 	"github.com/hellofresh/github-cli/pkg/log"
 	"github.com/hellofresh/github-cli/pkg/repo"
+	"github.com/hellofresh/github-cli/pkg/step"
 )
 
 // CreateRepoOptions are the flags for the create repository command
@@ -29,17 +31,21 @@ type CreateRepoOptions struct {
 	HasIssues            bool
 	HasWiki              bool
 	HasPages             bool
+	// Settings are the settings given on the command line, they take precedence over the configured ones
+	Settings config.RepositorySettings
 }
 
 // NewCreateRepoCmd creates a new create repo command
 func NewCreateRepoCmd(ctx context.Context) *cobra.Command {
 	opts := &CreateRepoOptions{}
+	settingsFlags := &repoSettingsFlags{}
 
 	cmd := &cobra.Command{
 		Use:   "create [name]",
 		Short: "Creates a new github repository",
 		Long:  `Creates a new github repository based on the rules defined on your .github.toml`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Settings = settingsFlags.settings(cmd.Flags())
 			return RunCreateRepo(ctx, args[0], opts)
 		},
 		Args: func(cmd *cobra.Command, args []string) error {
@@ -58,22 +64,24 @@ func NewCreateRepoCmd(ctx context.Context) *cobra.Command {
 	cmd.Flags().BoolVar(&opts.HasWiki, "has-wiki", false, "Enables wiki pages?")
 	cmd.Flags().BoolVar(&opts.HasPages, "has-pages", false, "Enables github pages?")
 	cmd.Flags().BoolVar(&opts.HasTeams, "has-teams", true, "Enable teams")
+	cmd.Flags().BoolVar(&opts.HasCollaborators, "has-collaborators", true, "Enable collaborators")
 	cmd.Flags().BoolVar(&opts.HasLabels, "has-labels", true, "Enable labels")
-	cmd.Flags().BoolVar(&opts.HasDefaultLabels, "rm-default-labels", true, "Removes the default github labels")
+	cmd.Flags().BoolVar(&opts.HasDefaultLabels, "rm-default-labels", true, "Removes GitHub's default labels that are not configured")
 	cmd.Flags().BoolVar(&opts.HasWebhooks, "has-webhooks", false, "Enables webhooks configurations")
 	cmd.Flags().BoolVar(&opts.HasBranchProtections, "has-branch-protections", true, "Enables branch protections")
+	settingsFlags.register(cmd.Flags())
 
 	return cmd
 }
 
 // RunCreateRepo runs the command to create a new repository
 func RunCreateRepo(ctx context.Context, repoName string, opts *CreateRepoOptions) error {
-	wg, ctx := errgroup.WithContext(ctx)
+	log.AddField(ctx, log.FieldRepo, repoName)
 	logger := log.WithContext(ctx)
 	cfg := config.WithContext(ctx)
-	githubClient := gh.WithContext(ctx)
-	if githubClient == nil {
-		return errors.New("failed to get github client")
+	creator, err := newGithubRepo(ctx)
+	if err != nil {
+		return err
 	}
 
 	org := cfg.Github.Organization
@@ -91,95 +99,138 @@ func RunCreateRepo(ctx context.Context, repoName string, opts *CreateRepoOptions
 	description := opts.Description
 	githubOpts := &repo.GithubRepoOpts{
 		Labels: &repo.LabelsOpts{
-			RemoveDefaultLabels: cfg.Github.RemoveDefaultLabels,
+			RemoveDefaultLabels: cfg.Github.RemoveDefaultLabels && opts.HasDefaultLabels,
 			Labels:              cfg.Github.Labels,
 		},
 		Teams:             cfg.Github.Teams,
+		Collaborators:     cfg.Github.Collaborators,
 		Webhooks:          cfg.Github.Webhooks,
 		BranchProtections: cfg.Github.Protections,
 	}
 
-	creator := repo.NewGithub(githubClient)
-
-	logger.Infof("Creating repository %s/%s...", org, repoName)
-	ghRepo, err := creator.CreateRepo(ctx, org, &github.Repository{
-		Name:        github.String(repoName),
-		Description: github.String(description),
-		Private:     github.Bool(opts.Private),
-		HasIssues:   github.Bool(opts.HasIssues),
-		HasWiki:     github.Bool(opts.HasWiki),
-		HasPages:    github.Bool(opts.HasPages),
-		AutoInit:    github.Bool(true),
+	settings := cfg.Github.Settings.Override(opts.Settings)
+
+	var ghRepo *github.Repository
+	steps := step.New()
+
+	steps.Add("create", func(ctx context.Context) error {
+		logger.Infof("Creating repository %s/%s...", org, repoName)
+		newRepo := &github.Repository{
+			Name:        github.String(repoName),
+			Description: github.String(description),
+			Private:     github.Bool(opts.Private),
+			HasIssues:   github.Bool(opts.HasIssues),
+			HasWiki:     github.Bool(opts.HasWiki),
+			HasPages:    github.Bool(opts.HasPages),
+			AutoInit:    github.Bool(true),
+		}
+		if settings.Visibility != "" {
+			newRepo.Private = nil
+			newRepo.Visibility = github.String(settings.Visibility)
+		}
+
+		var err error
+		ghRepo, err = creator.CreateRepo(ctx, org, newRepo)
+		if errors.Is(err, repo.ErrRepositoryAlreadyExists) {
+			logger.Info("Repository already exists. Trying to normalize it...")
+		} else if err != nil {
+			return fmt.Errorf("could not create repository: %w", err)
+		}
+
+		return nil
 	})
-	if errors.Is(err, repo.ErrRepositoryAlreadyExists) {
-		logger.Info("Repository already exists. Trying to normalize it...")
-	} else if err != nil {
-		return fmt.Errorf("could not create repository: %w", err)
+
+	if !reflect.ValueOf(settings).IsZero() {
+		steps.Add("settings", func(ctx context.Context) error {
+			logger.Info("Applying repository settings...")
+			if err := creator.ApplySettings(ctx, repoName, org, &settings); err != nil {
+				return fmt.Errorf("could not apply repository settings: %w", err)
+			}
+
+			return nil
+		}, "create")
 	}
 
 	if opts.HasTeams {
-		wg.Go(func() error {
+		steps.Add("teams", func(ctx context.Context) error {
 			logger.Info("Adding teams to repository...")
-			err = creator.AddTeamsToRepo(ctx, repoName, org, githubOpts.Teams)
-			if err != nil {
+			if err := creator.AddTeamsToRepo(ctx, repoName, org, githubOpts.Teams); err != nil {
 				return fmt.Errorf("could not add teams to repository: %w", err)
 			}
 
 			return nil
-		})
+		}, "create")
 	}
 
 	if opts.HasCollaborators {
-		wg.Go(func() error {
+		steps.Add("collaborators", func(ctx context.Context) error {
 			logger.Info("Adding collaborators to repository...")
-			if err = creator.AddCollaborators(ctx, repoName, org, githubOpts.Collaborators); err != nil {
+			results, err := creator.AddCollaborators(ctx, repoName, org, githubOpts.Collaborators)
+			logCollaborators(logger, results)
+			if err != nil {
 				return fmt.Errorf("could not add collaborators to repository: %w", err)
 			}
 
 			return nil
-		})
+		}, "create")
 	}
 
 	if opts.HasLabels {
-		wg.Go(func() error {
+		steps.Add("labels", func(ctx context.Context) error {
 			logger.Info("Adding labels to repository...")
-			err = creator.AddLabelsToRepo(ctx, repoName, org, githubOpts.Labels)
-			if errors.Is(err, repo.ErrLabeAlreadyExists) {
-				logger.Debug("Labels already exists, moving on...")
-			} else if err != nil {
+			if err := creator.AddLabelsToRepo(ctx, repoName, org, githubOpts.Labels); err != nil {
 				return fmt.Errorf("could not add labels to repository: %w", err)
 			}
 
 			return nil
-		})
+		}, "create")
 	}
 
 	if opts.HasWebhooks {
-		wg.Go(func() error {
+		steps.Add("webhooks", func(ctx context.Context) error {
 			logger.Info("Adding webhooks to repository...")
-			err = creator.AddWebhooksToRepo(ctx, repoName, org, githubOpts.Webhooks)
-			if errors.Is(err, repo.ErrWebhookAlreadyExist) {
-				logger.Debug("Webhook already exists, moving on...")
-			} else if err != nil {
+			if err := creator.AddWebhooksToRepo(ctx, repoName, org, githubOpts.Webhooks); err != nil {
 				return fmt.Errorf("could not add webhooks to repository: %w", err)
 			}
 
 			return nil
-		})
+		}, "create")
+	}
+
+	// protections can only be added to branches that exist
+	if opts.HasBranchProtections || settings.DefaultBranch != "" {
+		steps.Add("default branch", func(ctx context.Context) error {
+			branch, err := creator.DefaultBranch(ctx, repoName, org)
+			if err != nil {
+				return fmt.Errorf("could not find the default branch: %w", err)
+			}
+			logger.Debugf("Default branch %s is ready", branch)
+
+			if settings.DefaultBranch != "" && settings.DefaultBranch != branch {
+				logger.Infof("Changing the default branch to %s...", settings.DefaultBranch)
+				if err := creator.SetDefaultBranch(ctx, repoName, org, settings.DefaultBranch); err != nil {
+					return fmt.Errorf("could not change the default branch: %w", err)
+				}
+			}
+
+			return nil
+		}, "create")
 	}
 
 	if opts.HasBranchProtections {
-		wg.Go(func() error {
+		steps.Add("protections", func(ctx context.Context) error {
 			logger.Info("Adding branch protections to repository...")
-			if err = creator.AddBranchProtections(ctx, repoName, org, githubOpts.BranchProtections); err != nil {
+			if err := creator.AddBranchProtections(ctx, repoName, org, githubOpts.BranchProtections); err != nil {
 				return fmt.Errorf("could not add branch protections to repository: %w", err)
 			}
 
 			return nil
-		})
+		}, "default branch")
 	}
 
-	if err := wg.Wait(); err != nil {
+	results, err := steps.Run(ctx)
+	logSteps(logger, results)
+	if err != nil {
 		return err
 	}
 
@@ -191,3 +242,36 @@ func RunCreateRepo(ctx context.Context, repoName string, opts *CreateRepoOptions
 
 	return nil
 }
+
+// logSteps reports which steps succeeded, failed or were skipped
+func logSteps(logger *logrus.Logger, results []*step.Result) {
+	for _, result := range results {
+		entry := logger.WithFields(logrus.Fields{
+			log.FieldStep:     result.Name,
+			log.FieldDuration: result.Duration.Seconds(),
+		})
+		switch result.Status {
+		case step.StatusSucceeded:
+			entry.Debugf("Succeeded in %s", result.Duration.Round(time.Millisecond))
+		case step.StatusFailed:
+			entry.WithError(result.Err).Error("Failed")
+		case step.StatusSkipped:
+			entry.Warnf("Skipped, %s", result.Err)
+		}
+	}
+}
+
+// logCollaborators reports which collaborators got access and which have to accept an invitation
+func logCollaborators(logger *logrus.Logger, results []*repo.CollaboratorResult) {
+	for _, result := range results {
+		entry := logger.WithField("collaborator", result.Username)
+		switch result.Status {
+		case repo.CollaboratorInvited:
+			entry.Infof("Invitation sent with %s permission, it has to be accepted", result.Permission)
+		case repo.CollaboratorPending:
+			entry.Infof("Pending invitation updated to %s permission", result.Permission)
+		default:
+			entry.Infof("Granted %s access", result.Permission)
+		}
+	}
+}
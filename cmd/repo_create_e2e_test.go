@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-github/v33/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hellofresh/github-cli/pkg/config"
+	"github.com/hellofresh/github-cli/pkg/test"
+)
+
+// createOpts are the defaults of the create command flags
+func createOpts() *CreateRepoOptions {
+	return &CreateRepoOptions{
+		Private:              true,
+		HasIssues:            true,
+		HasTeams:             true,
+		HasCollaborators:     true,
+		HasLabels:            true,
+		HasDefaultLabels:     true,
+		HasBranchProtections: true,
+	}
+}
+
+func TestCreateRepoE2E(t *testing.T) {
+	fake := test.NewFakeGithub()
+	fake.AddOrg("hellofresh")
+	fake.AddMember("hellofresh", "alice")
+	teamID := fake.AddTeam("hellofresh", "platform")
+
+	ctx := newFakeContext(t, fake, &config.Spec{Github: config.Github{
+		Organization:  "hellofresh",
+		Teams:         []*config.Team{{ID: int(teamID), Permission: "push"}},
+		Collaborators: []*config.Collaborator{{Username: "alice", Permission: "admin"}, {Username: "bob", Permission: "pull"}},
+		Labels:        []*config.Label{{Name: "type: bug", Color: "d73a4a"}},
+		Protections:   config.BranchProtections{"main": {"ci/build"}},
+		Settings:      config.RepositorySettings{Topics: []string{"go"}, DeleteBranchOnMerge: github.Bool(true)},
+	}})
+
+	opts := createOpts()
+	opts.Description = "A service"
+	require.NoError(t, RunCreateRepo(ctx, "svc", opts))
+
+	r := fake.Repo("hellofresh", "svc")
+	require.NotNil(t, r)
+	assert.Equal(t, "A service", r.Data["description"])
+	assert.Equal(t, true, r.Data["private"])
+	assert.Equal(t, true, r.Data["delete_branch_on_merge"])
+	assert.Equal(t, map[string]string{"platform": "push"}, r.Teams)
+	assert.Equal(t, map[string]string{"alice": "admin"}, r.Collaborators)
+	require.Len(t, r.Invitations, 1)
+	assert.Equal(t, "bob", r.Invitations[0].GetInvitee().GetLogin())
+	assert.Equal(t, []string{"type: bug"}, labelNames(r))
+	require.Contains(t, r.Protections, "main")
+	assert.Equal(t, []string{"ci/build"}, r.Protections["main"].GetRequiredStatusChecks().Contexts)
+}
+
+func TestCreateRepoE2EDefaultBranch(t *testing.T) {
+	fake := test.NewFakeGithub()
+	fake.AddOrg("hellofresh")
+
+	ctx := newFakeContext(t, fake, &config.Spec{Github: config.Github{
+		Organization: "hellofresh",
+		Protections:  config.BranchProtections{"main": {"ci/build"}},
+		Settings:     config.RepositorySettings{DefaultBranch: "develop"},
+	}})
+
+	require.NoError(t, RunCreateRepo(ctx, "svc", createOpts()))
+
+	r := fake.Repo("hellofresh", "svc")
+	require.NotNil(t, r)
+	assert.Equal(t, "develop", r.Data["default_branch"])
+	assert.Contains(t, r.Protections, "main", "the protections of the previous default branch are kept")
+
+	client := fake.Client()
+	main, _, err := client.Repositories.GetBranch(context.Background(), "hellofresh", "svc", "main")
+	require.NoError(t, err)
+	develop, _, err := client.Repositories.GetBranch(context.Background(), "hellofresh", "svc", "develop")
+	require.NoError(t, err)
+	assert.Equal(t, main.GetCommit().GetSHA(), develop.GetCommit().GetSHA())
+}
+
+func TestCreateRepoE2ENormalizesExistingRepo(t *testing.T) {
+	fake := test.NewFakeGithub()
+	fake.AddOrg("hellofresh")
+	existing := fake.AddRepo("hellofresh", "svc", true)
+	existing.Labels = fakeLabels("bug", "wontfix", "team-x-priority", "needs review")
+	existing.Issues = map[int][]string{1: {"needs review"}, 2: {"team-x-priority"}}
+
+	ctx := newFakeContext(t, fake, &config.Spec{Github: config.Github{
+		Organization:        "hellofresh",
+		Labels:              []*config.Label{{Name: "type: bug", Color: "d73a4a", Aliases: []string{"bug"}}, {Name: "needs-review", Color: "ededed", Aliases: []string{"needs review"}}},
+		Protections:         config.BranchProtections{"main": {"ci/build"}},
+		Settings:            config.RepositorySettings{Homepage: "https://hellofresh.com"},
+		RemoveDefaultLabels: true,
+	}})
+
+	require.NoError(t, RunCreateRepo(ctx, "svc", createOpts()))
+
+	r := fake.Repo("hellofresh", "svc")
+	assert.Same(t, existing, r)
+	assert.Equal(t, "https://hellofresh.com", r.Data["homepage"])
+	assert.ElementsMatch(t, []string{"type: bug", "needs-review", "team-x-priority"}, labelNames(r), "custom labels are kept, default ones removed")
+	assert.Equal(t, map[int][]string{1: {"needs-review"}, 2: {"team-x-priority"}}, r.Issues)
+	assert.Contains(t, r.Protections, "main")
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v33/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/hellofresh/github-cli/pkg/config"
+	"github.com/hellofresh/github-cli/pkg/repo/repotest"
+)
+
+func TestRunCreateRepo(t *testing.T) {
+	newRepo := mock.MatchedBy(func(r *github.Repository) bool {
+		return r.GetName() == "svc" && r.GetPrivate() && r.GetAutoInit() && r.GetDescription() == "A service"
+	})
+	teams := func(m *repotest.Mocks) {
+		m.Organizations.On("Get", mock.Anything, "hellofresh").Return(&github.Organization{ID: github.Int64(42)}, &github.Response{}, nil)
+		m.Teams.On("AddTeamRepoByID", mock.Anything, int64(42), int64(7), "hellofresh", "svc", &github.TeamAddTeamRepoOptions{Permission: "push"}).
+			Return(&github.Response{}, nil)
+	}
+
+	tests := []struct {
+		name   string
+		org    string
+		setup  func(m *repotest.Mocks)
+		errMsg string
+	}{
+		{
+			name: "creates the repository",
+			org:  "hellofresh",
+			setup: func(m *repotest.Mocks) {
+				m.Repositories.On("Create", mock.Anything, "hellofresh", newRepo).
+					Return(&github.Repository{Name: github.String("svc"), GitURL: github.String("git://github.com/hellofresh/svc.git")}, &github.Response{}, nil)
+				teams(m)
+			},
+		},
+		{
+			name: "normalizes an existing repository",
+			org:  "hellofresh",
+			setup: func(m *repotest.Mocks) {
+				m.Repositories.On("Create", mock.Anything, "hellofresh", newRepo).Return(nil, nil, &github.ErrorResponse{
+					Response: &http.Response{StatusCode: http.StatusUnprocessableEntity},
+					Message:  "Repository creation failed.",
+				})
+				teams(m)
+			},
+		},
+		{
+			name: "skips the other steps when the repository can not be created",
+			org:  "hellofresh",
+			setup: func(m *repotest.Mocks) {
+				m.Repositories.On("Create", mock.Anything, "hellofresh", newRepo).Return(nil, nil, errors.New("500 Internal Server Error"))
+			},
+			errMsg: "1 error occurred:\n\t* could not create repository: 500 Internal Server Error\n\n",
+		},
+		{
+			name: "reports the steps that failed",
+			org:  "hellofresh",
+			setup: func(m *repotest.Mocks) {
+				m.Repositories.On("Create", mock.Anything, "hellofresh", newRepo).Return(&github.Repository{Name: github.String("svc")}, &github.Response{}, nil)
+				m.Organizations.On("Get", mock.Anything, "hellofresh").Return(nil, nil, errors.New("404 Not Found"))
+			},
+			errMsg: "1 error occurred:\n\t* could not add teams to repository: 404 Not Found\n\n",
+		},
+		{
+			name:   "missing organization",
+			setup:  func(m *repotest.Mocks) {},
+			errMsg: "please provide an organization",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocks := repotest.NewMocks()
+			tt.setup(mocks)
+			ctx := newMockContext(mocks, &config.Spec{Github: config.Github{
+				Organization: tt.org,
+				Teams:        []*config.Team{{ID: 7, Permission: "push"}},
+			}})
+
+			err := RunCreateRepo(ctx, "svc", &CreateRepoOptions{Description: "A service", Private: true, HasTeams: true})
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.errMsg)
+			}
+
+			mocks.AssertExpectations(t)
+		})
+	}
+}
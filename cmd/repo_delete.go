@@ -9,7 +9,6 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/hellofresh/github-cli/pkg/config"
-	gh "github.com/hellofresh/github-cli/pkg/github"
 	"github.com/hellofresh/github-cli/pkg/log"
 )
 
@@ -27,7 +26,8 @@ func NewDeleteRepoCmd(ctx context.Context) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return RunDeleteRepo(ctx, args[0], opts)
 		},
-		Args: cobra.MinimumNArgs(1),
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completeRepos(ctx, 0),
 	}
 
 	return cmd
@@ -35,11 +35,12 @@ func NewDeleteRepoCmd(ctx context.Context) *cobra.Command {
 
 // RunDeleteRepo runs the command to delete a repository
 func RunDeleteRepo(ctx context.Context, name string, opts *DeleteRepoOpts) error {
+	log.AddField(ctx, log.FieldRepo, name)
 	logger := log.WithContext(ctx)
 	cfg := config.WithContext(ctx)
-	githubClient := gh.WithContext(ctx)
-	if githubClient == nil {
-		return errors.New("failed to get github client")
+	creator, err := newGithubRepo(ctx)
+	if err != nil {
+		return err
 	}
 
 	org := cfg.Github.Organization
@@ -48,7 +49,7 @@ func RunDeleteRepo(ctx context.Context, name string, opts *DeleteRepoOpts) error
 	}
 
 	logger.Debug("Fetching repo details from Github")
-	_, _, err := githubClient.Repositories.Get(ctx, org, name)
+	_, _, err = creator.Repositories.Get(ctx, org, name)
 
 	if err != nil {
 		if strings.Contains(err.Error(), "404 Not Found") {
@@ -58,7 +59,7 @@ func RunDeleteRepo(ctx context.Context, name string, opts *DeleteRepoOpts) error
 		return fmt.Errorf("unexpected error when tried to get a repository info: %w", err)
 	}
 
-	_, err = githubClient.Repositories.Delete(ctx, org, name)
+	_, err = creator.Repositories.Delete(ctx, org, name)
 	if err != nil {
 		return fmt.Errorf("could not delete repository: %w", err)
 	}
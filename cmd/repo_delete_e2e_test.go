@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hellofresh/github-cli/pkg/config"
+	"github.com/hellofresh/github-cli/pkg/test"
+)
+
+func TestDeleteRepoE2E(t *testing.T) {
+	fake := test.NewFakeGithub()
+	fake.AddOrg("hellofresh")
+	fake.AddRepo("hellofresh", "svc", true)
+	fake.AddRepo("hellofresh", "other", true)
+
+	ctx := newFakeContext(t, fake, &config.Spec{Github: config.Github{Organization: "hellofresh"}})
+
+	require.NoError(t, RunDeleteRepo(ctx, "svc", &DeleteRepoOpts{}))
+
+	assert.Nil(t, fake.Repo("hellofresh", "svc"))
+	assert.NotNil(t, fake.Repo("hellofresh", "other"))
+}
+
+func TestDeleteRepoE2EMissingRepo(t *testing.T) {
+	fake := test.NewFakeGithub()
+	fake.AddOrg("hellofresh")
+
+	ctx := newFakeContext(t, fake, &config.Spec{Github: config.Github{Organization: "hellofresh"}})
+
+	err := RunDeleteRepo(ctx, "svc", &DeleteRepoOpts{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "github repo does not exist or you do not have access")
+	assert.NotContains(t, fake.Requests(), "DELETE /repos/hellofresh/svc")
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-github/v33/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/hellofresh/github-cli/pkg/config"
+	"github.com/hellofresh/github-cli/pkg/repo/repotest"
+)
+
+func TestRunDeleteRepo(t *testing.T) {
+	tests := []struct {
+		name   string
+		setup  func(m *repotest.Mocks)
+		org    string
+		errMsg string
+	}{
+		{
+			name: "deletes the repository",
+			org:  "hellofresh",
+			setup: func(m *repotest.Mocks) {
+				m.Repositories.On("Get", mock.Anything, "hellofresh", "svc").Return(&github.Repository{Name: github.String("svc")}, &github.Response{}, nil)
+				m.Repositories.On("Delete", mock.Anything, "hellofresh", "svc").Return(&github.Response{}, nil)
+			},
+		},
+		{
+			name: "missing repository",
+			org:  "hellofresh",
+			setup: func(m *repotest.Mocks) {
+				m.Repositories.On("Get", mock.Anything, "hellofresh", "svc").
+					Return(nil, nil, errors.New("GET https://api.github.com/repos/hellofresh/svc: 404 Not Found []"))
+			},
+			errMsg: "github repo does not exist or you do not have access: GET https://api.github.com/repos/hellofresh/svc: 404 Not Found []",
+		},
+		{
+			name: "repository can not be read",
+			org:  "hellofresh",
+			setup: func(m *repotest.Mocks) {
+				m.Repositories.On("Get", mock.Anything, "hellofresh", "svc").Return(nil, nil, errors.New("connection refused"))
+			},
+			errMsg: "unexpected error when tried to get a repository info: connection refused",
+		},
+		{
+			name: "repository can not be deleted",
+			org:  "hellofresh",
+			setup: func(m *repotest.Mocks) {
+				m.Repositories.On("Get", mock.Anything, "hellofresh", "svc").Return(&github.Repository{Name: github.String("svc")}, &github.Response{}, nil)
+				m.Repositories.On("Delete", mock.Anything, "hellofresh", "svc").Return(nil, errors.New("403 Must have admin rights"))
+			},
+			errMsg: "could not delete repository: 403 Must have admin rights",
+		},
+		{
+			name:   "missing organization",
+			setup:  func(m *repotest.Mocks) {},
+			errMsg: "please provide an organization",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocks := repotest.NewMocks()
+			tt.setup(mocks)
+			ctx := newMockContext(mocks, &config.Spec{Github: config.Github{Organization: tt.org}})
+
+			err := RunDeleteRepo(ctx, "svc", &DeleteRepoOpts{})
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.errMsg)
+			}
+
+			mocks.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/go-github/v33/github"
+	"github.com/spf13/cobra"
+
+	"github.com/hellofresh/github-cli/pkg/log"
+	"github.com/hellofresh/github-cli/pkg/repo"
+)
+
+// ListReposOpts are the flags for the list repositories command
+type ListReposOpts struct {
+	Filter       repo.Filter
+	Team         string
+	PushedBefore string
+	PushedAfter  string
+	Sort         string
+	Reverse      bool
+	Output       string
+}
+
+// repoListItem is a listed repository as printed in JSON and CSV
+type repoListItem struct {
+	Name          string    `json:"name"`
+	Visibility    string    `json:"visibility"`
+	Archived      bool      `json:"archived"`
+	Language      string    `json:"language"`
+	Topics        []string  `json:"topics"`
+	DefaultBranch string    `json:"default_branch"`
+	Stars         int       `json:"stars"`
+	PushedAt      time.Time `json:"pushed_at"`
+	CreatedAt     time.Time `json:"created_at"`
+	URL           string    `json:"url"`
+}
+
+// repoSorts are the orders repositories can be listed in
+var repoSorts = map[string]func(a, b *github.Repository) bool{
+	"name":    func(a, b *github.Repository) bool { return strings.ToLower(a.GetName()) < strings.ToLower(b.GetName()) },
+	"pushed":  func(a, b *github.Repository) bool { return a.GetPushedAt().Before(b.GetPushedAt().Time) },
+	"created": func(a, b *github.Repository) bool { return a.GetCreatedAt().Before(b.GetCreatedAt().Time) },
+	"stars":   func(a, b *github.Repository) bool { return a.GetStargazersCount() < b.GetStargazersCount() },
+	"size":    func(a, b *github.Repository) bool { return a.GetSize() < b.GetSize() },
+}
+
+// NewListReposCmd creates a new list repositories command
+func NewListReposCmd(ctx context.Context) *cobra.Command {
+	opts := &ListReposOpts{}
+	var archived bool
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "Lists the repositories of the organization",
+		Long: `Lists the repositories of the organization matching all the given filters, e.g.
+
+  github-cli repo list --language go --pushed-before 2021-01-01 --archived=false
+  github-cli repo list --team platform --name 'api-*' --output csv`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Filter.Archived = changedBool(cmd.Flags(), "archived", archived)
+			return RunListRepos(ctx, cmd.OutOrStdout(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Filter.Visibility, "visibility", "", "Only lists public, private or internal repositories")
+	cmd.Flags().BoolVar(&archived, "archived", false, "Only lists archived repositories, or active ones with --archived=false")
+	cmd.Flags().StringVar(&opts.Filter.Topic, "topic", "", "Only lists repositories with the topic")
+	cmd.Flags().StringVar(&opts.Filter.Language, "language", "", "Only lists repositories in the language")
+	cmd.Flags().StringVar(&opts.Filter.Name, "name", "", "Only lists repositories whose name matches the glob pattern")
+	cmd.Flags().StringVar(&opts.PushedBefore, "pushed-before", "", "Only lists repositories last pushed before the date, as YYYY-MM-DD or a duration ago like 720h")
+	cmd.Flags().StringVar(&opts.PushedAfter, "pushed-after", "", "Only lists repositories last pushed after the date, as YYYY-MM-DD or a duration ago like 720h")
+	cmd.Flags().StringVar(&opts.Team, "team", "", "Only lists repositories the team, given by its slug, has access to")
+	cmd.Flags().StringVar(&opts.Sort, "sort", "name", "Sorts by name, pushed, created, stars or size")
+	cmd.Flags().BoolVar(&opts.Reverse, "reverse", false, "Reverses the order")
+	cmd.Flags().StringVar(&opts.Output, "output", "table", "Output format: table, json or csv")
+
+	cmd.RegisterFlagCompletionFunc("team", completeTeams(ctx))
+
+	return cmd
+}
+
+// RunListRepos runs the command to list the repositories of the organization
+func RunListRepos(ctx context.Context, out io.Writer, opts *ListReposOpts) error {
+	logger := log.WithContext(ctx)
+
+	less, ok := repoSorts[opts.Sort]
+	if !ok {
+		return fmt.Errorf("invalid sort %q, expected name, pushed, created, stars or size", opts.Sort)
+	}
+	switch opts.Output {
+	case "table", "json", "csv":
+	default:
+		return fmt.Errorf("invalid output %q, expected table, json or csv", opts.Output)
+	}
+
+	var err error
+	filter := opts.Filter
+	if filter.PushedBefore, err = parseDateFlag(opts.PushedBefore); err != nil {
+		return fmt.Errorf("invalid --pushed-before: %w", err)
+	}
+	if filter.PushedAfter, err = parseDateFlag(opts.PushedAfter); err != nil {
+		return fmt.Errorf("invalid --pushed-after: %w", err)
+	}
+
+	creator, org, err := newOrgRepo(ctx)
+	if err != nil {
+		return err
+	}
+
+	if opts.Team != "" {
+		if filter.Repos, err = creator.TeamRepos(ctx, org, opts.Team); err != nil {
+			return fmt.Errorf("could not retrieve the repositories of team %s: %w", opts.Team, err)
+		}
+	}
+
+	logger.Debug("Fetching repositories...")
+	allRepos, err := creator.FetchAllRepos(ctx, org, 100, 1)
+	if err != nil {
+		return fmt.Errorf("could not retrieve repositories: %w", err)
+	}
+
+	repos := filter.Filter(allRepos)
+	sort.SliceStable(repos, func(i, j int) bool {
+		if opts.Reverse {
+			return less(repos[j], repos[i])
+		}
+		return less(repos[i], repos[j])
+	})
+	logger.Debugf("%d of %d repositories match", len(repos), len(allRepos))
+
+	items := make([]*repoListItem, 0, len(repos))
+	for _, r := range repos {
+		items = append(items, &repoListItem{
+			Name:          r.GetName(),
+			Visibility:    repo.Visibility(r),
+			Archived:      r.GetArchived(),
+			Language:      r.GetLanguage(),
+			Topics:        r.Topics,
+			DefaultBranch: r.GetDefaultBranch(),
+			Stars:         r.GetStargazersCount(),
+			PushedAt:      r.GetPushedAt().Time,
+			CreatedAt:     r.GetCreatedAt().Time,
+			URL:           r.GetHTMLURL(),
+		})
+	}
+
+	switch opts.Output {
+	case "json":
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(items)
+	case "csv":
+		w := csv.NewWriter(out)
+		w.Write([]string{"name", "visibility", "archived", "language", "topics", "default_branch", "stars", "pushed_at", "created_at", "url"})
+		for _, item := range items {
+			w.Write([]string{
+				item.Name, item.Visibility, strconv.FormatBool(item.Archived), item.Language, strings.Join(item.Topics, " "),
+				item.DefaultBranch, strconv.Itoa(item.Stars), item.PushedAt.Format(time.RFC3339), item.CreatedAt.Format(time.RFC3339), item.URL,
+			})
+		}
+		w.Flush()
+		return w.Error()
+	default:
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tVISIBILITY\tARCHIVED\tLANGUAGE\tTOPICS\tPUSHED")
+		for _, item := range items {
+			fmt.Fprintf(w, "%s\t%s\t%t\t%s\t%s\t%s\n", item.Name, item.Visibility, item.Archived, item.Language,
+				strings.Join(item.Topics, ","), item.PushedAt.Format("2006-01-02"))
+		}
+		return w.Flush()
+	}
+}
+
+// parseDateFlag parses a date as YYYY-MM-DD, RFC 3339, or a duration ago
+func parseDateFlag(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if d, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
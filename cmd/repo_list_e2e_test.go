@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hellofresh/github-cli/pkg/config"
+	"github.com/hellofresh/github-cli/pkg/repo"
+	"github.com/hellofresh/github-cli/pkg/test"
+)
+
+// listContext returns a context running the commands against a fake with the repositories of the repo
+// list tests, served two by two
+func listContext(t *testing.T) (context.Context, *test.FakeGithub) {
+	t.Helper()
+
+	fake := test.NewFakeGithub()
+	fake.PerPage = 2
+	fake.AddOrg("hellofresh")
+	fake.AddTeam("hellofresh", "platform")
+	ctx := newFakeContext(t, fake, &config.Spec{Github: config.Github{Organization: "hellofresh"}})
+
+	repos := []struct {
+		name     string
+		language string
+		stars    int
+		archived bool
+		private  bool
+		team     bool
+	}{
+		{name: "api-users", language: "Go", stars: 3, private: true, team: true},
+		{name: "api-orders", language: "Go", stars: 10, team: true},
+		{name: "web", language: "TypeScript", stars: 5, private: true},
+		{name: "legacy", language: "PHP", stars: 1, archived: true, private: true, team: true},
+		{name: "docs", stars: 7},
+	}
+	for _, tt := range repos {
+		r := fake.AddRepo("hellofresh", tt.name, false)
+		r.Data["language"] = tt.language
+		r.Data["stargazers_count"] = tt.stars
+		r.Data["archived"] = tt.archived
+		r.Data["private"] = tt.private
+		r.Data["visibility"] = map[bool]string{true: "private", false: "public"}[tt.private]
+		if tt.team {
+			r.Teams["platform"] = "push"
+		}
+	}
+
+	return ctx, fake
+}
+
+func TestListReposE2E(t *testing.T) {
+	active := false
+
+	tests := []struct {
+		name  string
+		opts  *ListReposOpts
+		repos []string
+	}{
+		{name: "all", opts: &ListReposOpts{}, repos: []string{"api-orders", "api-users", "docs", "legacy", "web"}},
+		{name: "by name pattern", opts: &ListReposOpts{Filter: repo.Filter{Name: "API-*"}}, repos: []string{"api-orders", "api-users"}},
+		{name: "by language", opts: &ListReposOpts{Filter: repo.Filter{Language: "go"}}, repos: []string{"api-orders", "api-users"}},
+		{name: "active only", opts: &ListReposOpts{Filter: repo.Filter{Archived: &active}}, repos: []string{"api-orders", "api-users", "docs", "web"}},
+		{name: "by visibility", opts: &ListReposOpts{Filter: repo.Filter{Visibility: "public"}}, repos: []string{"api-orders", "docs"}},
+		{name: "by team", opts: &ListReposOpts{Team: "platform"}, repos: []string{"api-orders", "api-users", "legacy"}},
+		{name: "by stars", opts: &ListReposOpts{Sort: "stars", Reverse: true}, repos: []string{"api-orders", "docs", "web", "api-users", "legacy"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := listContext(t)
+			out := &bytes.Buffer{}
+
+			tt.opts.Output = "json"
+			if tt.opts.Sort == "" {
+				tt.opts.Sort = "name"
+			}
+			require.NoError(t, RunListRepos(ctx, out, tt.opts))
+
+			var items []*repoListItem
+			require.NoError(t, json.Unmarshal(out.Bytes(), &items))
+
+			var names []string
+			for _, item := range items {
+				names = append(names, item.Name)
+			}
+			assert.Equal(t, tt.repos, names)
+		})
+	}
+}
+
+func TestListReposE2EOutputs(t *testing.T) {
+	ctx, fake := listContext(t)
+	out := &bytes.Buffer{}
+
+	require.NoError(t, RunListRepos(ctx, out, &ListReposOpts{Sort: "name", Output: "csv", Filter: repo.Filter{Name: "api-orders"}}))
+
+	records, err := csv.NewReader(out).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, []string{"name", "visibility", "archived", "language", "topics", "default_branch", "stars", "pushed_at", "created_at", "url"}, records[0])
+	assert.Equal(t, []string{"api-orders", "public", "false", "Go", "", "main", "10"}, records[1][:7])
+	assert.Equal(t, fake.URL+"/hellofresh/api-orders", records[1][9])
+
+	out.Reset()
+	require.NoError(t, RunListRepos(ctx, out, &ListReposOpts{Sort: "name", Output: "table"}))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 6)
+	assert.Equal(t, []string{"NAME", "VISIBILITY", "ARCHIVED", "LANGUAGE", "TOPICS", "PUSHED"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"api-orders", "public", "false", "Go"}, strings.Fields(lines[1])[:4])
+
+	assert.EqualError(t, RunListRepos(ctx, out, &ListReposOpts{Sort: "size", Output: "xml"}), "invalid output \"xml\", expected table, json or csv")
+	assert.EqualError(t, RunListRepos(ctx, out, &ListReposOpts{Sort: "forks", Output: "json"}), "invalid sort \"forks\", expected name, pushed, created, stars or size")
+}
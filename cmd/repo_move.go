@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/google/go-github/v33/github"
+
+	"github.com/hellofresh/github-cli/pkg/log"
+)
+
+// printRedirects tells what keeps working after a repository moved and what has to be updated
+func printRedirects(out io.Writer, oldRepo *github.Repository, newRepo *github.Repository) {
+	fmt.Fprintf(out, "%s moved to %s\n\n", oldRepo.GetFullName(), newRepo.GetFullName())
+	fmt.Fprintln(out, "Github redirects the old urls until a repository is created with the old name:")
+	fmt.Fprintf(out, "  %s -> %s\n", oldRepo.GetHTMLURL(), newRepo.GetHTMLURL())
+	fmt.Fprintf(out, "  %s -> %s\n", oldRepo.GetCloneURL(), newRepo.GetCloneURL())
+	fmt.Fprintf(out, "  %s -> %s\n\n", oldRepo.GetSSHURL(), newRepo.GetSSHURL())
+	fmt.Fprintln(out, "Update the remotes of your working copies with `git remote set-url origin "+newRepo.GetSSHURL()+"`,")
+	fmt.Fprintln(out, "and the references to the old name in CI configurations, GitHub Actions and GitHub Pages, which are not redirected.")
+}
+
+// updateGitRemotes points the remotes of the working copy in the current directory that use the old
+// repository urls to the new ones
+func updateGitRemotes(ctx context.Context, oldRepo *github.Repository, newRepo *github.Repository) error {
+	logger := log.WithContext(ctx)
+
+	r, err := git.PlainOpenWithOptions(".", &git.PlainOpenOptions{DetectDotGit: true})
+	if err == git.ErrRepositoryNotExists {
+		logger.Info("Not in a git working copy, no remotes to update")
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("could not open git working copy: %w", err)
+	}
+
+	cfg, err := r.Config()
+	if err != nil {
+		return fmt.Errorf("could not read git configuration: %w", err)
+	}
+
+	replacements := map[string]string{
+		oldRepo.GetCloneURL(): newRepo.GetCloneURL(),
+		oldRepo.GetSSHURL():   newRepo.GetSSHURL(),
+		oldRepo.GetGitURL():   newRepo.GetGitURL(),
+		oldRepo.GetHTMLURL():  newRepo.GetHTMLURL(),
+	}
+
+	updated := 0
+	for name, remote := range cfg.Remotes {
+		for i, url := range remote.URLs {
+			newURL, ok := replacements[url]
+			if !ok {
+				// remotes are often added without the .git suffix
+				newURL, ok = replacements[url+".git"]
+				newURL = strings.TrimSuffix(newURL, ".git")
+			}
+			if !ok || newURL == "" {
+				continue
+			}
+
+			logger.Infof("Remote %s: %s -> %s", name, url, newURL)
+			remote.URLs[i] = newURL
+			updated++
+		}
+	}
+
+	if updated == 0 {
+		logger.Info("No remote of the working copy uses the old repository")
+		return nil
+	}
+
+	if err := r.SetConfig(cfg); err != nil {
+		return fmt.Errorf("could not update git remotes: %w", err)
+	}
+
+	return nil
+}
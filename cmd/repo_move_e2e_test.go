@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hellofresh/github-cli/pkg/config"
+	"github.com/hellofresh/github-cli/pkg/test"
+)
+
+// workingCopy creates a git working copy with the given remotes and makes it the current directory
+func workingCopy(t *testing.T, remotes map[string]string) *git.Repository {
+	t.Helper()
+
+	dir := t.TempDir()
+	r, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+	for name, url := range remotes {
+		_, err := r.CreateRemote(&gitconfig.RemoteConfig{Name: name, URLs: []string{url}})
+		require.NoError(t, err)
+	}
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	return r
+}
+
+// remoteURL returns the url of a remote of a working copy
+func remoteURL(t *testing.T, r *git.Repository, name string) string {
+	t.Helper()
+
+	remote, err := r.Remote(name)
+	require.NoError(t, err)
+
+	return remote.Config().URLs[0]
+}
+
+func TestRenameRepoE2E(t *testing.T) {
+	fake := test.NewFakeGithub()
+	fake.AddOrg("hellofresh")
+
+	ctx := newFakeContext(t, fake, &config.Spec{Github: config.Github{Organization: "hellofresh"}})
+	r := fake.AddRepo("hellofresh", "svc", true)
+	oldSSH := r.Data["ssh_url"].(string)
+	oldHTML := r.Data["html_url"].(string)
+
+	wc := workingCopy(t, map[string]string{"origin": oldSSH, "web": oldHTML, "upstream": "git@github.com:hellofresh/other.git"})
+
+	cmd := NewRenameRepoCmd(ctx)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	require.NoError(t, RunRenameRepo(ctx, cmd, "svc", "users-svc", &RenameRepoOpts{UpdateRemotes: true}))
+
+	assert.Nil(t, fake.Repo("hellofresh", "svc"))
+	assert.Same(t, r, fake.Repo("hellofresh", "users-svc"))
+	assert.Contains(t, out.String(), "hellofresh/svc moved to hellofresh/users-svc\n")
+	assert.Contains(t, out.String(), oldSSH+" -> "+r.Data["ssh_url"].(string))
+
+	assert.Equal(t, r.Data["ssh_url"], remoteURL(t, wc, "origin"))
+	assert.Equal(t, r.Data["html_url"], remoteURL(t, wc, "web"))
+	assert.Equal(t, "git@github.com:hellofresh/other.git", remoteURL(t, wc, "upstream"))
+}
+
+func TestRenameRepoE2EExistingName(t *testing.T) {
+	fake := test.NewFakeGithub()
+	fake.AddOrg("hellofresh")
+
+	ctx := newFakeContext(t, fake, &config.Spec{Github: config.Github{Organization: "hellofresh"}})
+	fake.AddRepo("hellofresh", "svc", true)
+	fake.AddRepo("hellofresh", "users-svc", true)
+
+	cmd := NewRenameRepoCmd(ctx)
+	cmd.SetOut(&bytes.Buffer{})
+	err := RunRenameRepo(ctx, cmd, "svc", "users-svc", &RenameRepoOpts{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "could not rename repository")
+	assert.NotNil(t, fake.Repo("hellofresh", "svc"))
+}
+
+func TestTransferRepoE2E(t *testing.T) {
+	fake := test.NewFakeGithub()
+	// the teams, labels and protections of the destination are listed across pages
+	fake.PerPage = 1
+	fake.AddOrg("hellofresh")
+	fake.AddOrg("hellofresh-oss")
+	fake.AddTeam("hellofresh", "platform")
+	teamID := fake.AddTeam("hellofresh-oss", "maintainers")
+
+	ctx := newFakeContext(t, fake, &config.Spec{
+		Github: config.Github{Organization: "hellofresh"},
+		Contexts: map[string]*config.Github{"oss": {
+			Organization: "hellofresh-oss",
+			Teams:        []*config.Team{{ID: int(teamID), Permission: "maintain"}},
+			Labels:       []*config.Label{{Name: "good first issue", Color: "7057ff"}, {Name: "bug", Color: "d73a4a"}},
+			Protections:  config.BranchProtections{"main": {"ci/build"}},
+		}},
+	})
+	r := fake.AddRepo("hellofresh", "svc", true)
+	r.Teams["platform"] = "push"
+	r.Labels = fakeLabels("bug", "internal")
+	oldClone := r.Data["clone_url"].(string)
+
+	wc := workingCopy(t, map[string]string{"origin": oldClone})
+
+	cmd := NewTransferRepoCmd(ctx)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	require.NoError(t, RunTransferRepo(ctx, cmd, "svc", &TransferRepoOpts{To: "hellofresh-oss", UpdateRemotes: true}))
+
+	assert.Nil(t, fake.Repo("hellofresh", "svc"))
+	assert.Same(t, r, fake.Repo("hellofresh-oss", "svc"))
+	assert.Equal(t, map[string]string{"maintainers": "maintain"}, r.Teams)
+	assert.Equal(t, []string{"bug", "internal", "good first issue"}, labelNames(r), "the labels in use are kept")
+	assert.Equal(t, "d73a4a", r.Labels[0].GetColor())
+	require.Contains(t, r.Protections, "main")
+	assert.Equal(t, []string{"ci/build"}, r.Protections["main"].GetRequiredStatusChecks().Contexts)
+
+	assert.Contains(t, out.String(), "hellofresh/svc moved to hellofresh-oss/svc\n")
+	assert.Equal(t, r.Data["clone_url"], remoteURL(t, wc, "origin"))
+}
+
+func TestTransferRepoE2EUnconfiguredOrganization(t *testing.T) {
+	fake := test.NewFakeGithub()
+	fake.AddOrg("hellofresh")
+	fake.AddOrg("acquired-brand")
+
+	ctx := newFakeContext(t, fake, &config.Spec{Github: config.Github{
+		Organization: "hellofresh",
+		Labels:       []*config.Label{{Name: "type: bug", Color: "d73a4a"}},
+	}})
+	r := fake.AddRepo("hellofresh", "svc", true)
+	r.Labels = fakeLabels("bug")
+
+	cmd := NewTransferRepoCmd(ctx)
+	cmd.SetOut(&bytes.Buffer{})
+	require.NoError(t, RunTransferRepo(ctx, cmd, "svc", &TransferRepoOpts{To: "acquired-brand"}))
+
+	assert.Same(t, r, fake.Repo("acquired-brand", "svc"))
+	assert.Equal(t, []string{"bug"}, labelNames(r), "nothing is applied to an organization that is not configured")
+}
+
+func TestTransferRepoE2EMissingOrganization(t *testing.T) {
+	fake := test.NewFakeGithub()
+	fake.AddOrg("hellofresh")
+
+	ctx := newFakeContext(t, fake, &config.Spec{Github: config.Github{Organization: "hellofresh"}})
+	fake.AddRepo("hellofresh", "svc", true)
+
+	cmd := NewTransferRepoCmd(ctx)
+	cmd.SetOut(&bytes.Buffer{})
+	err := RunTransferRepo(ctx, cmd, "svc", &TransferRepoOpts{To: "missing"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "could not transfer repository")
+	assert.NotNil(t, fake.Repo("hellofresh", "svc"))
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hellofresh/github-cli/pkg/log"
+)
+
+// RenameRepoOpts are the flags for the rename repo command
+type RenameRepoOpts struct {
+	UpdateRemotes bool
+}
+
+// NewRenameRepoCmd creates a new rename repo command
+func NewRenameRepoCmd(ctx context.Context) *cobra.Command {
+	opts := &RenameRepoOpts{}
+
+	cmd := &cobra.Command{
+		Use:   "rename [old] [new]",
+		Short: "Renames a github repository",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunRenameRepo(ctx, cmd, args[0], args[1], opts)
+		},
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 2 || args[0] == "" || args[1] == "" {
+				return errors.New("please provide the current and the new repository names")
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.UpdateRemotes, "update-remotes", false, "Updates the remotes of the git working copy in the current directory")
+
+	return cmd
+}
+
+// RunRenameRepo runs the command to rename a repository
+func RunRenameRepo(ctx context.Context, cmd *cobra.Command, oldName string, newName string, opts *RenameRepoOpts) error {
+	log.AddField(ctx, log.FieldRepo, oldName)
+	logger := log.WithContext(ctx)
+
+	creator, org, err := newOrgRepo(ctx)
+	if err != nil {
+		return err
+	}
+
+	oldRepo, _, err := creator.Repositories.Get(ctx, org, oldName)
+	if err != nil {
+		return fmt.Errorf("could not retrieve repository: %w", err)
+	}
+
+	logger.Infof("Renaming repository %s/%s to %s...", org, oldName, newName)
+	newRepo, err := creator.RenameRepo(ctx, org, oldName, newName)
+	if err != nil {
+		return fmt.Errorf("could not rename repository: %w", err)
+	}
+
+	printRedirects(cmd.OutOrStdout(), oldRepo, newRepo)
+
+	if opts.UpdateRemotes {
+		return updateGitRemotes(ctx, oldRepo, newRepo)
+	}
+
+	return nil
+}
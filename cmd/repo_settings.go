@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"github.com/spf13/pflag"
+
+	"github.com/hellofresh/github-cli/pkg/config"
+)
+
+// repoSettingsFlags are the flags of the repository settings, shared by the create and update commands
+type repoSettingsFlags struct {
+	visibility                string
+	homepage                  string
+	defaultBranch             string
+	topics                    []string
+	allowMergeCommit          bool
+	allowSquashMerge          bool
+	allowRebaseMerge          bool
+	allowAutoMerge            bool
+	deleteBranchOnMerge       bool
+	hasProjects               bool
+	hasDiscussions            bool
+	vulnerabilityAlerts       bool
+	dependabotSecurityUpdates bool
+	secretScanning            bool
+}
+
+func (f *repoSettingsFlags) register(flags *pflag.FlagSet) {
+	flags.StringVar(&f.visibility, "visibility", "", "The repository's visibility: public, private or internal, takes precedence over --private")
+	flags.StringVar(&f.homepage, "homepage", "", "The repository's homepage url")
+	flags.StringVar(&f.defaultBranch, "default-branch", "", "The name of the default branch")
+	flags.StringSliceVar(&f.topics, "topic", nil, "The repository's topics, replacing the existing ones")
+	flags.BoolVar(&f.allowMergeCommit, "allow-merge-commit", false, "Allows merging pull requests with a merge commit")
+	flags.BoolVar(&f.allowSquashMerge, "allow-squash-merge", false, "Allows squash merging pull requests")
+	flags.BoolVar(&f.allowRebaseMerge, "allow-rebase-merge", false, "Allows rebase merging pull requests")
+	flags.BoolVar(&f.allowAutoMerge, "allow-auto-merge", false, "Allows pull requests to merge automatically once the requirements are met")
+	flags.BoolVar(&f.deleteBranchOnMerge, "delete-branch-on-merge", false, "Deletes head branches once their pull request is merged")
+	flags.BoolVar(&f.hasProjects, "has-projects", false, "Enables projects")
+	flags.BoolVar(&f.hasDiscussions, "has-discussions", false, "Enables discussions")
+	flags.BoolVar(&f.vulnerabilityAlerts, "vulnerability-alerts", false, "Enables Dependabot alerts for vulnerable dependencies")
+	flags.BoolVar(&f.dependabotSecurityUpdates, "dependabot-security-updates", false, "Enables Dependabot security updates, requires vulnerability alerts")
+	flags.BoolVar(&f.secretScanning, "secret-scanning", false, "Enables secret scanning")
+}
+
+// settings returns the settings given on the command line, the flags that were not given are left unset
+// so the configured settings and the current ones on github are kept
+func (f *repoSettingsFlags) settings(flags *pflag.FlagSet) config.RepositorySettings {
+	settings := config.RepositorySettings{
+		Visibility:                f.visibility,
+		Homepage:                  f.homepage,
+		DefaultBranch:             f.defaultBranch,
+		AllowMergeCommit:          changedBool(flags, "allow-merge-commit", f.allowMergeCommit),
+		AllowSquashMerge:          changedBool(flags, "allow-squash-merge", f.allowSquashMerge),
+		AllowRebaseMerge:          changedBool(flags, "allow-rebase-merge", f.allowRebaseMerge),
+		AllowAutoMerge:            changedBool(flags, "allow-auto-merge", f.allowAutoMerge),
+		DeleteBranchOnMerge:       changedBool(flags, "delete-branch-on-merge", f.deleteBranchOnMerge),
+		HasProjects:               changedBool(flags, "has-projects", f.hasProjects),
+		HasDiscussions:            changedBool(flags, "has-discussions", f.hasDiscussions),
+		VulnerabilityAlerts:       changedBool(flags, "vulnerability-alerts", f.vulnerabilityAlerts),
+		DependabotSecurityUpdates: changedBool(flags, "dependabot-security-updates", f.dependabotSecurityUpdates),
+		SecretScanning:            changedBool(flags, "secret-scanning", f.secretScanning),
+	}
+	if flags.Changed("topic") {
+		settings.Topics = append([]string{}, f.topics...)
+	}
+
+	return settings
+}
+
+// changedBool returns the value of a flag only when it was given
+func changedBool(flags *pflag.FlagSet, name string, value bool) *bool {
+	if !flags.Changed(name) {
+		return nil
+	}
+
+	return &value
+}
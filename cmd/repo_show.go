@@ -0,0 +1,318 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hellofresh/github-cli/pkg/config"
+	"github.com/hellofresh/github-cli/pkg/log"
+	"github.com/hellofresh/github-cli/pkg/repo"
+	"github.com/hellofresh/github-cli/pkg/step"
+)
+
+type (
+	// ShowRepoOpts are the flags for the show repo command
+	ShowRepoOpts struct {
+		Output string
+	}
+
+	// repoDetails is everything the CLI manages for a repository
+	repoDetails struct {
+		Name                 string                     `json:"name"`
+		Description          string                     `json:"description"`
+		URL                  string                     `json:"url"`
+		Archived             bool                       `json:"archived"`
+		Settings             *config.RepositorySettings `json:"settings"`
+		Teams                []*repoAccess              `json:"teams"`
+		OutsideCollaborators []*repoAccess              `json:"outside_collaborators"`
+		Invitations          []*repoAccess              `json:"invitations"`
+		Labels               []*config.Label            `json:"labels"`
+		Webhooks             []*repoWebhook             `json:"webhooks"`
+		Protections          []*repoProtection          `json:"protections"`
+		// Errors are the sections that could not be read, e.g. webhooks require admin access
+		Errors map[string]string `json:"errors,omitempty"`
+	}
+
+	repoAccess struct {
+		Name       string `json:"name"`
+		Permission string `json:"permission"`
+	}
+
+	repoWebhook struct {
+		ID           int64              `json:"id"`
+		URL          string             `json:"url"`
+		Events       []string           `json:"events"`
+		Active       bool               `json:"active"`
+		LastDelivery *repo.HookDelivery `json:"last_delivery"`
+	}
+
+	repoProtection struct {
+		Branch          string   `json:"branch"`
+		StatusChecks    []string `json:"status_checks"`
+		StrictChecks    bool     `json:"strict_checks"`
+		RequiredReviews int      `json:"required_reviews"`
+		CodeOwners      bool     `json:"code_owners"`
+		EnforceAdmins   bool     `json:"enforce_admins"`
+	}
+)
+
+// NewShowRepoCmd creates a new show repo command
+func NewShowRepoCmd(ctx context.Context) *cobra.Command {
+	opts := &ShowRepoOpts{}
+
+	cmd := &cobra.Command{
+		Use:   "show [name]",
+		Short: "Shows everything github-cli manages for a repository",
+		Long:  `Shows the settings, teams, collaborators, invitations, labels, webhooks and branch protections of a repository`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunShowRepo(ctx, cmd.OutOrStdout(), args[0], opts)
+		},
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 || args[0] == "" {
+				return errors.New("please provide a repository name")
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Output, "output", "text", "Output format: text or json")
+
+	return cmd
+}
+
+// RunShowRepo runs the command to show a repository
+func RunShowRepo(ctx context.Context, out io.Writer, repoName string, opts *ShowRepoOpts) error {
+	log.AddField(ctx, log.FieldRepo, repoName)
+	if opts.Output != "text" && opts.Output != "json" {
+		return fmt.Errorf("invalid output %q, expected text or json", opts.Output)
+	}
+
+	creator, org, err := newOrgRepo(ctx)
+	if err != nil {
+		return err
+	}
+
+	ghRepo, _, err := creator.Repositories.Get(ctx, org, repoName)
+	if err != nil {
+		return fmt.Errorf("could not retrieve repository: %w", err)
+	}
+
+	details := &repoDetails{
+		Name:        ghRepo.GetFullName(),
+		Description: ghRepo.GetDescription(),
+		URL:         ghRepo.GetHTMLURL(),
+		Archived:    ghRepo.GetArchived(),
+	}
+
+	// the sections are independent, each one only writes its own field
+	steps := step.New()
+	steps.Add("settings", func(ctx context.Context) (err error) {
+		details.Settings, err = creator.Settings(ctx, repoName, org)
+		return err
+	})
+	steps.Add("teams", func(ctx context.Context) error {
+		teams, err := creator.ListTeams(ctx, repoName, org)
+		for _, team := range teams {
+			details.Teams = append(details.Teams, &repoAccess{Name: team.GetSlug(), Permission: team.GetPermission()})
+		}
+		return err
+	})
+	steps.Add("outside collaborators", func(ctx context.Context) error {
+		users, err := creator.ListOutsideCollaborators(ctx, repoName, org)
+		for _, user := range users {
+			details.OutsideCollaborators = append(details.OutsideCollaborators, &repoAccess{Name: user.GetLogin(), Permission: highestPermission(user.GetPermissions())})
+		}
+		return err
+	})
+	steps.Add("invitations", func(ctx context.Context) error {
+		invitations, err := creator.ListInvitations(ctx, repoName, org)
+		for _, invitation := range invitations {
+			details.Invitations = append(details.Invitations, &repoAccess{Name: invitation.GetInvitee().GetLogin(), Permission: invitation.GetPermissions()})
+		}
+		return err
+	})
+	steps.Add("labels", func(ctx context.Context) error {
+		labels, err := creator.ListLabels(ctx, repoName, org)
+		for _, label := range labels {
+			details.Labels = append(details.Labels, &config.Label{Name: label.GetName(), Color: label.GetColor(), Description: label.GetDescription()})
+		}
+		return err
+	})
+	steps.Add("webhooks", func(ctx context.Context) error {
+		hooks, err := creator.ListWebhooks(ctx, repoName, org)
+		for _, hook := range hooks {
+			webhook := &repoWebhook{ID: hook.GetID(), URL: fmt.Sprint(hook.Config["url"]), Events: hook.Events, Active: hook.GetActive()}
+			if deliveries, err := creator.ListWebhookDeliveries(ctx, repoName, org, hook.GetID(), 1); err == nil && len(deliveries) > 0 {
+				webhook.LastDelivery = deliveries[0]
+			}
+			details.Webhooks = append(details.Webhooks, webhook)
+		}
+		return err
+	})
+	steps.Add("protections", func(ctx context.Context) (err error) {
+		details.Protections, err = branchProtections(ctx, creator, org, repoName)
+		return err
+	})
+
+	results, _ := steps.Run(ctx)
+	for _, result := range results {
+		if result.Err != nil {
+			if details.Errors == nil {
+				details.Errors = make(map[string]string)
+			}
+			details.Errors[result.Name] = result.Err.Error()
+		}
+	}
+
+	if opts.Output == "json" {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(details)
+	}
+
+	printRepoDetails(out, details)
+
+	return nil
+}
+
+// branchProtections returns the protections of all the protected branches
+func branchProtections(ctx context.Context, creator *repo.GithubRepo, org string, repoName string) ([]*repoProtection, error) {
+	branches, err := creator.ListProtectedBranches(ctx, repoName, org)
+	if err != nil {
+		return nil, err
+	}
+
+	var protections []*repoProtection
+	for _, branch := range branches {
+		protection, resp, err := creator.Repositories.GetBranchProtection(ctx, org, repoName, branch.GetName())
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			continue
+		} else if err != nil {
+			return protections, err
+		}
+
+		p := &repoProtection{Branch: branch.GetName()}
+		if ea := protection.GetEnforceAdmins(); ea != nil {
+			p.EnforceAdmins = ea.Enabled
+		}
+		if checks := protection.GetRequiredStatusChecks(); checks != nil {
+			p.StatusChecks, p.StrictChecks = checks.Contexts, checks.Strict
+		}
+		if reviews := protection.GetRequiredPullRequestReviews(); reviews != nil {
+			p.RequiredReviews, p.CodeOwners = reviews.RequiredApprovingReviewCount, reviews.RequireCodeOwnerReviews
+		}
+		protections = append(protections, p)
+	}
+
+	return protections, nil
+}
+
+// highestPermission returns the strongest permission a user has on a repository
+func highestPermission(permissions map[string]bool) string {
+	for _, permission := range []string{"admin", "maintain", "push", "triage", "pull"} {
+		if permissions[permission] {
+			return permission
+		}
+	}
+
+	return ""
+}
+
+func printRepoDetails(out io.Writer, d *repoDetails) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	section := func(name string, empty bool) bool {
+		fmt.Fprintf(w, "\n%s\n", strings.ToUpper(name))
+		if err, ok := d.Errors[name]; ok {
+			fmt.Fprintf(w, "  could not be read: %s\n", err)
+			return false
+		}
+		if empty {
+			fmt.Fprintln(w, "  none")
+			return false
+		}
+		return true
+	}
+
+	fmt.Fprintf(w, "%s\n", d.Name)
+	if d.Description != "" {
+		fmt.Fprintf(w, "%s\n", d.Description)
+	}
+	fmt.Fprintf(w, "%s\n", d.URL)
+	if d.Archived {
+		fmt.Fprintln(w, "This repository is archived")
+	}
+
+	if section("settings", d.Settings == nil) {
+		s := d.Settings
+		fmt.Fprintf(w, "  Visibility\t%s\n", s.Visibility)
+		fmt.Fprintf(w, "  Default branch\t%s\n", s.DefaultBranch)
+		fmt.Fprintf(w, "  Topics\t%s\n", strings.Join(s.Topics, ", "))
+		fmt.Fprintf(w, "  Homepage\t%s\n", s.Homepage)
+		fmt.Fprintf(w, "  Merge commits\t%s\n", formatSetting(s.AllowMergeCommit))
+		fmt.Fprintf(w, "  Squash merging\t%s\n", formatSetting(s.AllowSquashMerge))
+		fmt.Fprintf(w, "  Rebase merging\t%s\n", formatSetting(s.AllowRebaseMerge))
+		fmt.Fprintf(w, "  Auto merge\t%s\n", formatSetting(s.AllowAutoMerge))
+		fmt.Fprintf(w, "  Delete branch on merge\t%s\n", formatSetting(s.DeleteBranchOnMerge))
+		fmt.Fprintf(w, "  Projects\t%s\n", formatSetting(s.HasProjects))
+		fmt.Fprintf(w, "  Discussions\t%s\n", formatSetting(s.HasDiscussions))
+		fmt.Fprintf(w, "  Vulnerability alerts\t%s\n", formatSetting(s.VulnerabilityAlerts))
+		fmt.Fprintf(w, "  Dependabot security updates\t%s\n", formatSetting(s.DependabotSecurityUpdates))
+		fmt.Fprintf(w, "  Secret scanning\t%s\n", formatSetting(s.SecretScanning))
+	}
+
+	for _, access := range []struct {
+		name string
+		list []*repoAccess
+	}{{"teams", d.Teams}, {"outside collaborators", d.OutsideCollaborators}, {"invitations", d.Invitations}} {
+		if section(access.name, len(access.list) == 0) {
+			for _, a := range access.list {
+				fmt.Fprintf(w, "  %s\t%s\n", a.Name, a.Permission)
+			}
+		}
+	}
+
+	if section("labels", len(d.Labels) == 0) {
+		for _, label := range d.Labels {
+			fmt.Fprintf(w, "  %s\t#%s\t%s\n", label.Name, label.Color, label.Description)
+		}
+	}
+
+	if section("webhooks", len(d.Webhooks) == 0) {
+		for _, hook := range d.Webhooks {
+			lastDelivery := "never delivered"
+			if delivery := hook.LastDelivery; delivery != nil {
+				lastDelivery = fmt.Sprintf("%d %s at %s", delivery.StatusCode, delivery.Status, delivery.DeliveredAt.Format("2006-01-02 15:04"))
+			}
+			fmt.Fprintf(w, "  %d\t%s\t%s\tactive: %t\t%s\n", hook.ID, hook.URL, strings.Join(hook.Events, ","), hook.Active, lastDelivery)
+		}
+	}
+
+	if section("protections", len(d.Protections) == 0) {
+		for _, p := range d.Protections {
+			fmt.Fprintf(w, "  %s\tchecks: %s\treviews: %d\tcode owners: %t\tenforce admins: %t\n",
+				p.Branch, strings.Join(p.StatusChecks, ","), p.RequiredReviews, p.CodeOwners, p.EnforceAdmins)
+		}
+	}
+
+	w.Flush()
+}
+
+func formatSetting(value *bool) string {
+	switch {
+	case value == nil:
+		return "unknown"
+	case *value:
+		return "enabled"
+	}
+
+	return "disabled"
+}
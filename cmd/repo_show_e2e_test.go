@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v33/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hellofresh/github-cli/pkg/config"
+	"github.com/hellofresh/github-cli/pkg/test"
+)
+
+// showContext returns a context running the commands against a fake with a fully configured repository,
+// its lists are served one item per page
+func showContext(t *testing.T) (context.Context, *test.FakeGithub) {
+	t.Helper()
+
+	fake := test.NewFakeGithub()
+	fake.PerPage = 1
+	fake.AddOrg("hellofresh")
+	fake.AddMember("hellofresh", "carol")
+	fake.AddTeam("hellofresh", "platform")
+	fake.AddTeam("hellofresh", "security")
+	ctx := newFakeContext(t, fake, &config.Spec{Github: config.Github{Organization: "hellofresh"}})
+
+	r := fake.AddRepo("hellofresh", "svc", true)
+	r.Data["description"] = "A service"
+	r.Data["topics"] = []string{"go"}
+	r.Teams["platform"] = "push"
+	r.Teams["security"] = "pull"
+	r.Collaborators["alice"] = "admin"
+	r.Collaborators["bob"] = "push"
+	r.Collaborators["carol"] = "push"
+	r.Invitations = []*github.RepositoryInvitation{{ID: github.Int64(1), Invitee: &github.User{Login: github.String("dave")}, Permissions: github.String("read")}}
+	r.Labels = fakeLabels("bug", "needs-review")
+	r.Hooks = []*github.Hook{fakeHook(1, "https://ci.example.com/hook", "push")}
+	fake.AddDelivery("hellofresh", "svc", 1, http.StatusOK, "ping")
+	fake.AddDelivery("hellofresh", "svc", 1, http.StatusBadGateway, "push")
+
+	client := fake.Client()
+	main, _, err := client.Repositories.GetBranch(context.Background(), "hellofresh", "svc", "main")
+	require.NoError(t, err)
+	_, _, err = client.Git.CreateRef(context.Background(), "hellofresh", "svc", &github.Reference{
+		Ref:    github.String("refs/heads/develop"),
+		Object: &github.GitObject{SHA: main.GetCommit().SHA},
+	})
+	require.NoError(t, err)
+
+	r.Protections["main"] = &github.Protection{
+		RequiredStatusChecks:       &github.RequiredStatusChecks{Strict: true, Contexts: []string{"ci/build"}},
+		RequiredPullRequestReviews: &github.PullRequestReviewsEnforcement{RequiredApprovingReviewCount: 1},
+		EnforceAdmins:              &github.AdminEnforcement{Enabled: true},
+	}
+	// the API omits enforce_admins when it is not readable
+	r.Protections["develop"] = &github.Protection{RequiredStatusChecks: &github.RequiredStatusChecks{Contexts: []string{"ci/test"}}}
+
+	return ctx, fake
+}
+
+func TestShowRepoE2EJSON(t *testing.T) {
+	ctx, fake := showContext(t)
+
+	var out bytes.Buffer
+	require.NoError(t, RunShowRepo(ctx, &out, "svc", &ShowRepoOpts{Output: "json"}))
+
+	var details repoDetails
+	require.NoError(t, json.Unmarshal(out.Bytes(), &details))
+
+	assert.Equal(t, "hellofresh/svc", details.Name)
+	assert.Equal(t, "A service", details.Description)
+	assert.Equal(t, fake.URL+"/hellofresh/svc", details.URL)
+	assert.Empty(t, details.Errors)
+
+	require.NotNil(t, details.Settings)
+	assert.Equal(t, "public", details.Settings.Visibility)
+	assert.Equal(t, "main", details.Settings.DefaultBranch)
+	assert.Equal(t, []string{"go"}, details.Settings.Topics)
+
+	assert.Equal(t, []*repoAccess{{Name: "platform", Permission: "push"}, {Name: "security", Permission: "pull"}}, details.Teams)
+	assert.Equal(t, []*repoAccess{{Name: "alice", Permission: "admin"}, {Name: "bob", Permission: "push"}}, details.OutsideCollaborators, "members are not outside collaborators")
+	assert.Equal(t, []*repoAccess{{Name: "dave", Permission: "read"}}, details.Invitations)
+	assert.Equal(t, []*config.Label{{Name: "bug", Color: "ededed"}, {Name: "needs-review", Color: "ededed"}}, details.Labels)
+
+	require.Len(t, details.Webhooks, 1)
+	assert.Equal(t, "https://ci.example.com/hook", details.Webhooks[0].URL)
+	require.NotNil(t, details.Webhooks[0].LastDelivery)
+	assert.Equal(t, "push", details.Webhooks[0].LastDelivery.Event, "the last delivery is the most recent one")
+
+	assert.Equal(t, []*repoProtection{
+		{Branch: "develop", StatusChecks: []string{"ci/test"}},
+		{Branch: "main", StatusChecks: []string{"ci/build"}, StrictChecks: true, RequiredReviews: 1, EnforceAdmins: true},
+	}, details.Protections)
+}
+
+func TestShowRepoE2EText(t *testing.T) {
+	ctx, _ := showContext(t)
+
+	var out bytes.Buffer
+	require.NoError(t, RunShowRepo(ctx, &out, "svc", &ShowRepoOpts{Output: "text"}))
+
+	text := out.String()
+	for _, section := range []string{"SETTINGS", "TEAMS", "OUTSIDE COLLABORATORS", "INVITATIONS", "LABELS", "WEBHOOKS", "PROTECTIONS"} {
+		assert.Contains(t, text, "\n"+section+"\n")
+	}
+	assert.Contains(t, text, "502 Invalid HTTP Response: 502 at")
+
+	var protections []string
+	for _, line := range strings.Split(text, "\n") {
+		if strings.Contains(line, "enforce admins:") {
+			protections = append(protections, strings.Join(strings.Fields(line), " "))
+		}
+	}
+	assert.Equal(t, []string{
+		"develop checks: ci/test reviews: 0 code owners: false enforce admins: false",
+		"main checks: ci/build reviews: 1 code owners: false enforce admins: true",
+	}, protections)
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v33/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hellofresh/github-cli/pkg/repo/repotest"
+)
+
+func TestBranchProtections(t *testing.T) {
+	ok := &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}
+	notFound := &github.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}
+	forbidden := &github.Response{Response: &http.Response{StatusCode: http.StatusForbidden}}
+
+	tests := []struct {
+		name        string
+		protection  *github.Protection
+		resp        *github.Response
+		err         error
+		protections []*repoProtection
+		errMsg      string
+	}{
+		{
+			name: "all the protections",
+			protection: &github.Protection{
+				RequiredStatusChecks:       &github.RequiredStatusChecks{Strict: true, Contexts: []string{"ci/build"}},
+				RequiredPullRequestReviews: &github.PullRequestReviewsEnforcement{RequiredApprovingReviewCount: 2, RequireCodeOwnerReviews: true},
+				EnforceAdmins:              &github.AdminEnforcement{Enabled: true},
+			},
+			resp: ok,
+			protections: []*repoProtection{{
+				Branch:          "main",
+				StatusChecks:    []string{"ci/build"},
+				StrictChecks:    true,
+				RequiredReviews: 2,
+				CodeOwners:      true,
+				EnforceAdmins:   true,
+			}},
+		},
+		{
+			name:        "without enforce admins",
+			protection:  &github.Protection{RequiredStatusChecks: &github.RequiredStatusChecks{Contexts: []string{"ci/build"}}},
+			resp:        ok,
+			protections: []*repoProtection{{Branch: "main", StatusChecks: []string{"ci/build"}}},
+		},
+		{
+			name: "protection removed meanwhile",
+			resp: notFound,
+			err:  errors.New("404 Branch not protected"),
+		},
+		{
+			name:   "protection can not be read",
+			resp:   forbidden,
+			err:    errors.New("403 Must have admin rights"),
+			errMsg: "403 Must have admin rights",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocks := repotest.NewMocks()
+			mocks.Repositories.On("ListBranches", mock.Anything, "hellofresh", "svc", mock.Anything).
+				Return([]*github.Branch{{Name: github.String("main")}}, &github.Response{}, nil)
+			mocks.Repositories.On("GetBranchProtection", mock.Anything, "hellofresh", "svc", "main").
+				Return(tt.protection, tt.resp, tt.err)
+
+			protections, err := branchProtections(context.Background(), mocks.GithubRepo(), "hellofresh", "svc")
+			if tt.errMsg != "" {
+				assert.EqualError(t, err, tt.errMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.protections, protections)
+			mocks.AssertExpectations(t)
+		})
+	}
+}
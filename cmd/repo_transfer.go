@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/go-github/v33/github"
+	"github.com/spf13/cobra"
+
+	"github.com/hellofresh/github-cli/pkg/config"
+	"github.com/hellofresh/github-cli/pkg/log"
+	"github.com/hellofresh/github-cli/pkg/repo"
+	"github.com/hellofresh/github-cli/pkg/step"
+)
+
+// TransferRepoOpts are the flags for the transfer repo command
+type TransferRepoOpts struct {
+	To            string
+	UpdateRemotes bool
+}
+
+// NewTransferRepoCmd creates a new transfer repo command
+func NewTransferRepoCmd(ctx context.Context) *cobra.Command {
+	opts := &TransferRepoOpts{}
+
+	cmd := &cobra.Command{
+		Use:   "transfer [name]",
+		Short: "Transfers a github repository to another organization",
+		Long: `Transfers a github repository to another organization. When the destination organization is the
+one on your .github.toml, or one of its contexts, its teams, labels and branch protections are applied
+to the repository once it moved.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunTransferRepo(ctx, cmd, args[0], opts)
+		},
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 || args[0] == "" {
+				return errors.New("please provide a repository name")
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.To, "to", "", "The organization the repository is transferred to")
+	cmd.Flags().BoolVar(&opts.UpdateRemotes, "update-remotes", false, "Updates the remotes of the git working copy in the current directory")
+	cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+// RunTransferRepo runs the command to transfer a repository to another organization
+func RunTransferRepo(ctx context.Context, cmd *cobra.Command, repoName string, opts *TransferRepoOpts) error {
+	log.AddField(ctx, log.FieldRepo, repoName)
+	logger := log.WithContext(ctx)
+	cfg := config.WithContext(ctx)
+
+	creator, org, err := newOrgRepo(ctx)
+	if err != nil {
+		return err
+	}
+
+	oldRepo, _, err := creator.Repositories.Get(ctx, org, repoName)
+	if err != nil {
+		return fmt.Errorf("could not retrieve repository: %w", err)
+	}
+
+	var newRepo *github.Repository
+	steps := step.New()
+
+	steps.Add("transfer", func(ctx context.Context) error {
+		logger.Infof("Transferring repository %s/%s to %s...", org, repoName, opts.To)
+		transferred, err := creator.TransferRepo(ctx, org, repoName, opts.To)
+		if err != nil {
+			return fmt.Errorf("could not transfer repository: %w", err)
+		}
+		newRepo = transferred
+
+		return nil
+	})
+
+	destination, ok := cfg.Organization(opts.To)
+	if !ok {
+		logger.Warnf("%s is not configured, add it as a context to apply its teams, labels and protections", opts.To)
+	} else {
+		steps.Add("teams", func(ctx context.Context) error {
+			logger.Infof("Adding %s teams to repository...", opts.To)
+			if err := creator.AddTeamsToRepo(ctx, repoName, opts.To, destination.Teams); err != nil {
+				return fmt.Errorf("could not add teams to repository: %w", err)
+			}
+
+			return nil
+		}, "transfer")
+
+		steps.Add("labels", func(ctx context.Context) error {
+			logger.Infof("Adding %s labels to repository...", opts.To)
+			// the labels of the repository are in use, only the configured ones are added and updated
+			labels := &repo.LabelsOpts{Labels: destination.Labels}
+			if err := creator.AddLabelsToRepo(ctx, repoName, opts.To, labels); err != nil {
+				return fmt.Errorf("could not add labels to repository: %w", err)
+			}
+
+			return nil
+		}, "transfer")
+
+		steps.Add("protections", func(ctx context.Context) error {
+			logger.Infof("Adding %s branch protections to repository...", opts.To)
+			if err := creator.AddBranchProtections(ctx, repoName, opts.To, destination.Protections); err != nil {
+				return fmt.Errorf("could not add branch protections to repository: %w", err)
+			}
+
+			return nil
+		}, "transfer")
+	}
+
+	results, err := steps.Run(ctx)
+	logSteps(logger, results)
+	if newRepo == nil {
+		return err
+	}
+
+	printRedirects(cmd.OutOrStdout(), oldRepo, newRepo)
+
+	if opts.UpdateRemotes {
+		if remoteErr := updateGitRemotes(ctx, oldRepo, newRepo); remoteErr != nil {
+			logger.WithError(remoteErr).Error("Could not update git remotes")
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/google/go-github/v33/github"
+	"github.com/spf13/cobra"
+
+	"github.com/hellofresh/github-cli/pkg/config"
+	"github.com/hellofresh/github-cli/pkg/log"
+)
+
+// UpdateRepoOptions are the flags for the update repository command, only the flags that were
+// given are set and patched
+type UpdateRepoOptions struct {
+	Description *string
+	Private     *bool
+	HasIssues   *bool
+	HasWiki     *bool
+	HasPages    *bool
+	IsTemplate  *bool
+	Archived    *bool
+	// Settings are the settings given on the command line, they take precedence over the configured ones
+	Settings config.RepositorySettings
+	// ApplyConfig also applies the configured settings, by default only the flags are
+	ApplyConfig bool
+}
+
+// NewUpdateRepoCmd creates a new update repo command
+func NewUpdateRepoCmd(ctx context.Context) *cobra.Command {
+	opts := &UpdateRepoOptions{}
+	settingsFlags := &repoSettingsFlags{}
+
+	var (
+		description                                               string
+		private, hasIssues, hasWiki, hasPages, template, archived bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "update [name]",
+		Short: "Updates an existing github repository",
+		Long: `Changes the settings given as flags on an existing repository, everything else is left as it is.
+With --apply-config the repository settings defined on your .github.toml are applied as well, the flags take precedence.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags := cmd.Flags()
+			if flags.Changed("description") {
+				opts.Description = &description
+			}
+			opts.Private = changedBool(flags, "private", private)
+			opts.HasIssues = changedBool(flags, "has-issues", hasIssues)
+			opts.HasWiki = changedBool(flags, "has-wiki", hasWiki)
+			opts.HasPages = changedBool(flags, "has-pages", hasPages)
+			opts.IsTemplate = changedBool(flags, "template", template)
+			opts.Archived = changedBool(flags, "archived", archived)
+			opts.Settings = settingsFlags.settings(flags)
+
+			return RunUpdateRepo(ctx, args[0], opts)
+		},
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 || args[0] == "" {
+				return errors.New("please provide a repository name")
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&description, "description", "d", "", "The repository's description")
+	cmd.Flags().BoolVar(&private, "private", false, "Is the repository private?")
+	cmd.Flags().BoolVar(&hasIssues, "has-issues", false, "Enables issue pages")
+	cmd.Flags().BoolVar(&hasWiki, "has-wiki", false, "Enables wiki pages")
+	cmd.Flags().BoolVar(&hasPages, "has-pages", false, "Enables github pages, served from the default branch")
+	cmd.Flags().BoolVar(&template, "template", false, "Makes the repository a template")
+	cmd.Flags().BoolVar(&archived, "archived", false, "Archives the repository, which makes it read only")
+	cmd.Flags().BoolVar(&opts.ApplyConfig, "apply-config", false, "Also applies the repository settings of the configuration")
+	settingsFlags.register(cmd.Flags())
+
+	return cmd
+}
+
+// RunUpdateRepo runs the command to update a repository
+func RunUpdateRepo(ctx context.Context, repoName string, opts *UpdateRepoOptions) error {
+	log.AddField(ctx, log.FieldRepo, repoName)
+	logger := log.WithContext(ctx)
+	cfg := config.WithContext(ctx)
+
+	creator, org, err := newOrgRepo(ctx)
+	if err != nil {
+		return err
+	}
+
+	settings := opts.Settings
+	if opts.ApplyConfig {
+		settings = cfg.Github.Settings.Override(opts.Settings)
+	}
+
+	edit := &github.Repository{
+		Description: opts.Description,
+		Private:     opts.Private,
+		HasIssues:   opts.HasIssues,
+		HasWiki:     opts.HasWiki,
+		IsTemplate:  opts.IsTemplate,
+	}
+	if settings.Visibility != "" {
+		// the visibility takes precedence over the private flag
+		edit.Private = nil
+	}
+	// an archived repository is read only, so it is unarchived first and archived last
+	if opts.Archived != nil && !*opts.Archived {
+		edit.Archived = opts.Archived
+	}
+
+	if reflect.DeepEqual(edit, &github.Repository{}) && opts.HasPages == nil && opts.Archived == nil && reflect.ValueOf(settings).IsZero() {
+		return errors.New("nothing to update, please provide the flags to change or --apply-config with configured repository settings")
+	}
+
+	logger.Infof("Updating repository %s/%s...", org, repoName)
+	if !reflect.DeepEqual(edit, &github.Repository{}) {
+		logger.Debug("Patching repository")
+		if _, _, err := creator.Repositories.Edit(ctx, org, repoName, edit); err != nil {
+			return fmt.Errorf("could not update repository: %w", err)
+		}
+	}
+
+	if err := creator.ApplySettings(ctx, repoName, org, &settings); err != nil {
+		return fmt.Errorf("could not update repository: %w", err)
+	}
+
+	if settings.DefaultBranch != "" {
+		if err := creator.SetDefaultBranch(ctx, repoName, org, settings.DefaultBranch); err != nil {
+			return fmt.Errorf("could not change the default branch: %w", err)
+		}
+	}
+
+	if opts.HasPages != nil {
+		if err := creator.SetPages(ctx, repoName, org, *opts.HasPages); err != nil {
+			return fmt.Errorf("could not change github pages: %w", err)
+		}
+	}
+
+	if opts.Archived != nil && *opts.Archived {
+		logger.Debug("Archiving repository")
+		if _, _, err := creator.Repositories.Edit(ctx, org, repoName, &github.Repository{Archived: opts.Archived}); err != nil {
+			return fmt.Errorf("could not archive repository: %w", err)
+		}
+	}
+
+	logger.Info("Repository updated!")
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v33/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hellofresh/github-cli/pkg/config"
+	"github.com/hellofresh/github-cli/pkg/test"
+)
+
+func TestUpdateRepoE2E(t *testing.T) {
+	configured := config.RepositorySettings{Homepage: "https://hellofresh.com", AllowMergeCommit: github.Bool(false)}
+
+	tests := []struct {
+		name     string
+		opts     *UpdateRepoOptions
+		homepage string
+		merge    bool
+	}{
+		{
+			name:     "only the flags",
+			opts:     &UpdateRepoOptions{Description: github.String("New description"), HasWiki: github.Bool(false)},
+			homepage: "",
+			merge:    true,
+		},
+		{
+			name:     "with the configuration",
+			opts:     &UpdateRepoOptions{Description: github.String("New description"), HasWiki: github.Bool(false), ApplyConfig: true},
+			homepage: "https://hellofresh.com",
+			merge:    false,
+		},
+		{
+			name: "flags take precedence over the configuration",
+			opts: &UpdateRepoOptions{
+				Description: github.String("New description"),
+				HasWiki:     github.Bool(false),
+				Settings:    config.RepositorySettings{Homepage: "https://example.com"},
+				ApplyConfig: true,
+			},
+			homepage: "https://example.com",
+			merge:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := test.NewFakeGithub()
+			fake.AddOrg("hellofresh")
+			r := fake.AddRepo("hellofresh", "svc", true)
+
+			ctx := newFakeContext(t, fake, &config.Spec{Github: config.Github{Organization: "hellofresh", Settings: configured}})
+
+			require.NoError(t, RunUpdateRepo(ctx, "svc", tt.opts))
+
+			assert.Equal(t, "New description", r.Data["description"])
+			assert.Equal(t, false, r.Data["has_wiki"])
+			assert.Equal(t, tt.homepage, r.Data["homepage"])
+			assert.Equal(t, tt.merge, r.Data["allow_merge_commit"])
+			assert.Equal(t, false, r.Data["archived"])
+		})
+	}
+}
+
+func TestUpdateRepoE2EArchivesLast(t *testing.T) {
+	fake := test.NewFakeGithub()
+	fake.AddOrg("hellofresh")
+	r := fake.AddRepo("hellofresh", "svc", true)
+
+	ctx := newFakeContext(t, fake, &config.Spec{Github: config.Github{Organization: "hellofresh"}})
+
+	require.NoError(t, RunUpdateRepo(ctx, "svc", &UpdateRepoOptions{Description: github.String("Deprecated"), Archived: github.Bool(true)}))
+
+	assert.Equal(t, "Deprecated", r.Data["description"])
+	assert.Equal(t, true, r.Data["archived"])
+	assert.Equal(t, []string{"PATCH /repos/hellofresh/svc", "PATCH /repos/hellofresh/svc"}, patches(fake.Requests()))
+}
+
+func TestUpdateRepoE2ENothingToUpdate(t *testing.T) {
+	fake := test.NewFakeGithub()
+	fake.AddOrg("hellofresh")
+	fake.AddRepo("hellofresh", "svc", true)
+
+	ctx := newFakeContext(t, fake, &config.Spec{Github: config.Github{
+		Organization: "hellofresh",
+		Settings:     config.RepositorySettings{Homepage: "https://hellofresh.com"},
+	}})
+
+	err := RunUpdateRepo(ctx, "svc", &UpdateRepoOptions{})
+	assert.EqualError(t, err, "nothing to update, please provide the flags to change or --apply-config with configured repository settings")
+	assert.Empty(t, patches(fake.Requests()))
+}
+
+// patches returns the PATCH requests
+func patches(requests []string) []string {
+	var patches []string
+	for _, request := range requests {
+		if strings.HasPrefix(request, "PATCH ") {
+			patches = append(patches, request)
+		}
+	}
+
+	return patches
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -15,65 +16,160 @@ type (
 	// RootOptions represents the ahoy global options
 	RootOptions struct {
 		configFile string
+		context    string
 		token      string
 		org        string
 		verbose    bool
+		logFormat  string
+		logFile    string
 	}
 )
 
+// skipConfigAnnotation marks commands, and their subcommands, that run without loading the configuration
+const skipConfigAnnotation = "skip-config"
+
 // NewRootCmd creates the root command
 func NewRootCmd() *cobra.Command {
 	opts := RootOptions{}
 
+	ctx := log.NewContext(context.Background())
+	ctx = config.NewContext(ctx)
+	ctx, err := github.NewContext(ctx, "")
+	if err != nil {
+		log.WithContext(ctx).WithError(err).Fatal("could not create the github client")
+	}
+
+	closeLog := func() error { return nil }
+
 	cmd := cobra.Command{
 		Use:   "github-cli [--config] [--token]",
 		Short: "HF Github is a cli tool to manage your github repositories",
 		PersistentPreRun: func(ccmd *cobra.Command, args []string) {
 			if opts.verbose {
-				log.WithContext(context.Background()).SetLevel(logrus.DebugLevel)
+				log.WithContext(ctx).SetLevel(logrus.DebugLevel)
+			}
+
+			var err error
+			if closeLog, err = log.Configure(ctx, opts.logFormat, opts.logFile); err != nil {
+				log.WithContext(ctx).WithError(err).Fatal("Could not configure logging")
+			}
+
+			if !requiresConfig(ccmd) {
+				return
+			}
+
+			loadConfig(ctx, opts)
+
+			log.AddFields(ctx, logrus.Fields{
+				log.FieldCommand: strings.TrimPrefix(ccmd.CommandPath(), ccmd.Root().Name()+" "),
+				log.FieldOrg:     config.WithContext(ctx).Github.Organization,
+			})
+		},
+		PersistentPostRun: func(ccmd *cobra.Command, args []string) {
+			notifyUpdate(ctx, ccmd)
+
+			if err := closeLog(); err != nil {
+				log.WithContext(ctx).WithError(err).Warn("Could not close the log file")
 			}
 		},
 		Version: version,
+		// replaced by NewCompletionCmd, which runs without loading the configuration
+		CompletionOptions: cobra.CompletionOptions{DisableDefaultCmd: true},
 	}
 
-	cmd.PersistentFlags().StringVarP(&opts.configFile, "config", "c", "", "config file (default is $HOME/.github.toml)")
+	cmd.PersistentFlags().StringVarP(&opts.configFile, "config", "c", "", "config file in toml, yaml or json (default is ./.github.*, $XDG_CONFIG_HOME/github-cli/config.* or $HOME/.github.*)")
+	cmd.PersistentFlags().StringVar(&opts.context, "context", "", "Named organization context from the config file to use (default is CurrentContext)")
 	cmd.PersistentFlags().StringVarP(&opts.token, "token", "t", "", "optional, github token for authentication (default in $HOME/.github.toml)")
 	cmd.PersistentFlags().BoolVarP(&opts.verbose, "verbose", "v", false, "Make the operation more talkative")
 	cmd.PersistentFlags().StringVarP(&opts.org, "organization", "o", "", "Github's organization")
+	cmd.PersistentFlags().StringVar(&opts.logFormat, "log-format", "text", "Log format: text, json or logfmt")
+	cmd.PersistentFlags().StringVar(&opts.logFile, "log-file", "", "Appends the logs to this file instead of writing them to stderr")
 
-	ctx := log.NewContext(context.Background())
-	ctx, err := config.NewContext(ctx, opts.configFile)
-	if err != nil {
-		log.WithContext(ctx).WithError(err).Fatal("Could not load configuration file")
+	// Aggregates Root commands
+	cmd.AddCommand(NewRepoCmd(ctx))
+	cmd.AddCommand(NewHiringCmd(ctx))
+	cmd.AddCommand(NewWebhookCmd(ctx))
+	cmd.AddCommand(NewLabelsCmd(ctx))
+	cmd.AddCommand(NewConfigCmd(ctx, &opts))
+	cmd.AddCommand(NewVersionCmd(ctx, &opts))
+	cmd.AddCommand(NewUpdateCmd(ctx, &opts))
+	cmd.AddCommand(NewCompletionCmd(ctx))
+
+	return &cmd
+}
+
+// loadConfig reads and validates the configuration, applies the global flags on top of it and
+// authenticates the github client
+func loadConfig(ctx context.Context, opts RootOptions) {
+	logger := log.WithContext(ctx)
+
+	if err := config.Load(ctx, opts.configFile); err != nil {
+		logger.WithError(err).Fatal("Could not load configuration file")
 	}
 
 	cfg := config.WithContext(ctx)
+	if err := applyFlags(ctx, cfg, opts); err != nil {
+		logger.WithError(err).Fatal("Could not load configuration file")
+	}
+
+	if cfg.Github.Token == "" {
+		logger.Fatal("Github token not specified. Please set the GITHUB_TOKEN environment variable, set it in your config file, or provide it with the \"-t\" flag")
+	}
+
+	if err := cfg.Validate(); err != nil {
+		logValidationErrors(ctx, err)
+		logger.Fatal("Invalid configuration file, please fix the problems above")
+	}
+
+	// the token of the test organization is only used by git, it must not end up in recorded fixtures either
+	if err := github.Authenticate(ctx, cfg.Github.Token, cfg.Github.BaseURL, cfg.GithubTestOrg.Token); err != nil {
+		logger.WithError(err).Fatal("could not create the github client")
+	}
+}
+
+// applyFlags selects the context and overrides the configuration with the global flags
+func applyFlags(ctx context.Context, cfg *config.Spec, opts RootOptions) error {
+	name := opts.context
+	if name == "" {
+		name = cfg.CurrentContext
+	}
+
+	if name != "" {
+		if err := cfg.UseContext(ctx, name); err != nil {
+			return err
+		}
+	}
 
 	if opts.token != "" {
 		cfg.Github.Token = opts.token
 		cfg.GithubTestOrg.Token = opts.token
-	}
-
-	if cfg.Github.Token == "" {
-		log.WithContext(ctx).Fatal("Github token not specified. Please set the GITHUB_TOKEN environment variable, set it in your config file, or provide it with the \"-t\" flag")
+		cfg.SetSource("github.token", config.SourceFlag)
+		cfg.SetSource("githubtestorg.token", config.SourceFlag)
 	}
 
 	if opts.org != "" {
 		cfg.Github.Organization = opts.org
 		cfg.GithubTestOrg.Organization = opts.org
+		cfg.SetSource("github.organization", config.SourceFlag)
+		cfg.SetSource("githubtestorg.organization", config.SourceFlag)
 	}
-	ctx = config.OverrideConfig(ctx, cfg)
 
-	ctx, err = github.NewContext(ctx, cfg.Github.Token)
-	if err != nil {
-		log.WithContext(ctx).WithError(err).Fatal("could not create the kube client")
+	return nil
+}
+
+// requiresConfig checks if the command or any of its parents opted out of loading the configuration
+func requiresConfig(cmd *cobra.Command) bool {
+	// completions read the configuration themselves, see completionConfig
+	switch cmd.Name() {
+	case "help", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+		return false
 	}
 
-	// Aggregates Root commands
-	cmd.AddCommand(NewRepoCmd(ctx))
-	cmd.AddCommand(NewHiringCmd(ctx))
-	cmd.AddCommand(NewVersionCmd(ctx))
-	cmd.AddCommand(NewUpdateCmd(ctx))
+	for c := cmd; c != nil; c = c.Parent() {
+		if _, ok := c.Annotations[skipConfigAnnotation]; ok {
+			return false
+		}
+	}
 
-	return &cmd
+	return true
 }
@@ -4,89 +4,267 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"runtime"
-	"strings"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"time"
 
-	"github.com/hellofresh/updater-go/v3"
-	"github.com/sirupsen/logrus"
+	homedir "github.com/mitchellh/go-homedir"
 	"github.com/spf13/cobra"
 
 	"github.com/hellofresh/github-cli/pkg/config"
+	gh "github.com/hellofresh/github-cli/pkg/github"
 	"github.com/hellofresh/github-cli/pkg/log"
+	"github.com/hellofresh/github-cli/pkg/selfupdate"
 )
 
 const (
 	githubOwner = "hellofresh"
 	githubRepo  = "github-cli"
+
+	// noUpdateCheckEnv disables the update notice when set, so does the CI variable set by most CI services
+	noUpdateCheckEnv = "GHCLI_NO_UPDATE_CHECK"
+	// updateCheckTimeout bounds the delay the update notice adds to a command
+	updateCheckTimeout = 2 * time.Second
 )
 
 // UpdateOptions are the command flags
 type UpdateOptions struct {
-	timeout time.Duration
+	timeout   time.Duration
+	version   string
+	channel   string
+	publicKey string
+	check     bool
+	rollback  bool
 }
 
 // NewUpdateCmd creates a new update command
-func NewUpdateCmd(ctx context.Context) *cobra.Command {
+func NewUpdateCmd(ctx context.Context, rootOpts *RootOptions) *cobra.Command {
 	opts := &UpdateOptions{}
 
 	cmd := &cobra.Command{
 		Use:     "update",
 		Aliases: []string{"self-update"},
 		Short:   fmt.Sprintf("Check for new versions of %s", githubRepo),
+		Long: `Updates github-cli to the latest release. The downloaded release is verified against the checksums
+file of the release, and against its signature when a public key is configured. The replaced binary is kept
+so the update can be undone with --rollback.`,
+		// a configuration that can not be loaded must not prevent installing a release fixing it
+		Annotations: map[string]string{skipConfigAnnotation: "true"},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return RunUpdate(ctx, opts)
+			return RunUpdate(ctx, opts, rootOpts)
 		},
 	}
 
 	cmd.PersistentFlags().DurationVar(&opts.timeout, "timeout", 10*time.Second, "Request timeout when searching for new release")
+	cmd.Flags().StringVar(&opts.version, "version", "", "Release to install instead of the latest one, e.g. 1.2.0")
+	cmd.Flags().StringVar(&opts.channel, "channel", "", "Releases to update to: stable or prerelease, overrides Update.Channel")
+	cmd.Flags().StringVar(&opts.publicKey, "public-key", "", "Armored OpenPGP public key file the release checksums must be signed with, overrides Update.PublicKey")
+	cmd.Flags().BoolVar(&opts.check, "check", false, "Only report whether a new version is available")
+	cmd.Flags().BoolVar(&opts.rollback, "rollback", false, "Restore the binary replaced by the last update")
 
 	return cmd
 }
 
 // RunUpdate runs the update command
-func RunUpdate(ctx context.Context, opts *UpdateOptions) error {
+func RunUpdate(ctx context.Context, opts *UpdateOptions, rootOpts *RootOptions) error {
 	logger := log.WithContext(ctx)
+
+	if opts.rollback && (opts.check || opts.version != "") {
+		return errors.New("--rollback can not be combined with --check or --version")
+	}
+
+	// rolling back only swaps the binaries, it needs neither the configuration nor github
+	if opts.rollback {
+		if err := selfupdate.New(nil, githubOwner, githubRepo).Rollback(); err != nil {
+			return fmt.Errorf("could not roll back: %w", err)
+		}
+
+		logger.Info("Rolled back to the previous binary")
+		return nil
+	}
+
+	cfg, err := updateConfig(ctx, rootOpts)
+	if err != nil {
+		return err
+	}
+
+	updater, err := newUpdater(ctx, cfg, opts.channel)
+	if err != nil {
+		return err
+	}
+
+	if err := loadPublicKey(cfg, updater, opts.publicKey); err != nil {
+		return err
+	}
+
 	logger.Info("Checking if any new version is available...")
 
-	cfg := config.WithContext(ctx)
-
-	resolver := updater.NewGithubClient(
-		ctx,
-		githubOwner,
-		githubRepo,
-		cfg.Github.Token,
-		updater.StableRelease,
-		func(asset string) bool {
-			matchesFilter := strings.Contains(asset, fmt.Sprintf("_%s_%s", runtime.GOOS, runtime.GOARCH))
-			logger.WithFields(logrus.Fields{
-				"asset":    asset,
-				"filtered": matchesFilter,
-			}).Debug("Filtering release asset")
-
-			return matchesFilter
-		},
-		opts.timeout,
-	)
+	release, err := findRelease(ctx, updater, opts)
+	if err != nil {
+		return err
+	}
+
+	if opts.version == "" && !selfupdate.Newer(version, release.Version) || release.Version == version {
+		logger.Infof("You already have the version %s of %s/%s", version, githubOwner, githubRepo)
+		return nil
+	}
+
+	if opts.check {
+		logger.Infof("Version %s of %s/%s is available, you have %s", release.Version, githubOwner, githubRepo, version)
+		return nil
+	}
+
+	if err := updater.Apply(ctx, release); err != nil {
+		return fmt.Errorf("could not update to version %q: %w", release.Version, err)
+	}
+
+	logger.Infof("Updated to the version %s, run `%s update --rollback` to go back to %s", release.Version, githubRepo, version)
+
+	return nil
+}
+
+// updateConfig reads the update settings and the token from the configuration. The update runs without
+// them when the configuration can not be read, and anonymously when the token can not be resolved.
+func updateConfig(ctx context.Context, rootOpts *RootOptions) (*config.Spec, error) {
+	logger := log.WithContext(ctx)
+
+	cfg, err := config.Read(ctx, rootOpts.configFile)
+	if err != nil {
+		logger.WithError(err).Warn("Could not read the configuration, updating without the configured channel and public key")
+		cfg = &config.Spec{}
+	}
+
+	if err := cfg.ResolveTokens(ctx); err != nil {
+		logger.WithError(err).Warn("Could not resolve the github token, reading the releases anonymously")
+		cfg.Github.Token = ""
+	}
+
+	if err := applyFlags(ctx, cfg, *rootOpts); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// newUpdater creates an updater reading the releases from github.com, the configured token is only
+// used when it is not a GitHub Enterprise token. An empty channel means the configured one.
+func newUpdater(ctx context.Context, cfg *config.Spec, channel string) (*selfupdate.Updater, error) {
+	if channel == "" {
+		channel = cfg.Update.Channel
+	}
+	switch channel {
+	case "", config.ChannelStable, config.ChannelPrerelease:
+	default:
+		return nil, fmt.Errorf("invalid channel %q, expected %s or %s", channel, config.ChannelStable, config.ChannelPrerelease)
+	}
 
-	updateTo, err := updater.LatestRelease(ctx, resolver)
-	if rootErr := errors.Unwrap(err); rootErr == updater.ErrNoRepository {
-		return fmt.Errorf("unable to acceess %s/%s repository", githubOwner, githubRepo)
+	var token string
+	if cfg.Github.BaseURL == "" {
+		token = cfg.Github.Token
 	}
+
+	client, err := gh.NewClient(ctx, token, "")
 	if err != nil {
-		return fmt.Errorf("could not retrieve release for update: %w", err)
+		return nil, err
 	}
 
-	if updateTo.Name == version {
-		logger.Infof("You already have the latest version of %s/%s", githubOwner, githubRepo)
+	updater := selfupdate.New(client, githubOwner, githubRepo)
+	updater.Prerelease = channel == config.ChannelPrerelease
+
+	return updater, nil
+}
+
+// loadPublicKey reads the key the releases must be signed with, the flag takes precedence over the configuration
+func loadPublicKey(cfg *config.Spec, updater *selfupdate.Updater, publicKey string) error {
+	if publicKey == "" {
+		publicKey = cfg.Update.PublicKey
+	}
+	if publicKey == "" {
 		return nil
 	}
 
-	if err := updater.SelfUpdate(ctx, updateTo); err != nil {
-		return fmt.Errorf("could not update release to version %q", updateTo.Name)
+	file, err := homedir.Expand(publicKey)
+	if err != nil {
+		return err
 	}
 
-	logger.Infof("Updated to the version %s", updateTo.Name)
+	if updater.PublicKey, err = ioutil.ReadFile(file); err != nil {
+		return fmt.Errorf("could not read public key: %w", err)
+	}
 
 	return nil
 }
+
+func findRelease(ctx context.Context, updater *selfupdate.Updater, opts *UpdateOptions) (*selfupdate.Release, error) {
+	ctx, cancel := context.WithTimeout(ctx, opts.timeout)
+	defer cancel()
+
+	if opts.version != "" {
+		release, err := updater.Release(ctx, opts.version)
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve release for update: %w", err)
+		}
+
+		return release, nil
+	}
+
+	release, err := updater.Latest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve release for update: %w", err)
+	}
+
+	return release, nil
+}
+
+// notifyUpdate logs a notice when a newer version is available, the releases are looked up at most once a day
+func notifyUpdate(ctx context.Context, cmd *cobra.Command) {
+	if !updateCheckEnabled(cmd) {
+		return
+	}
+
+	logger := log.WithContext(ctx)
+
+	dir, err := config.StateDir()
+	if err != nil {
+		logger.WithError(err).Debug("Could not check for a new version")
+		return
+	}
+
+	updater, err := newUpdater(ctx, config.WithContext(ctx), "")
+	if err != nil {
+		logger.WithError(err).Debug("Could not check for a new version")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateCheckTimeout)
+	defer cancel()
+
+	latest, err := updater.Check(ctx, filepath.Join(dir, "update-check.json"))
+	if err != nil {
+		logger.WithError(err).Debug("Could not check for a new version")
+	}
+
+	if selfupdate.Newer(version, latest) {
+		logger.Infof("A new version of %s is available: %s (you have %s), run `%s update` to install it", githubRepo, latest, version, githubRepo)
+	}
+}
+
+// updateCheckEnabled tells whether the update notice is shown after the command
+func updateCheckEnabled(cmd *cobra.Command) bool {
+	if version == devVersion || os.Getenv(noUpdateCheckEnv) != "" || os.Getenv("CI") != "" {
+		return false
+	}
+
+	// fixtures must not depend on the releases published when they are recorded or replayed
+	if os.Getenv(gh.RecordEnv) != "" || os.Getenv(gh.ReplayEnv) != "" {
+		return false
+	}
+
+	switch cmd.Name() {
+	case "update", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hellofresh/github-cli/pkg/config"
+	"github.com/hellofresh/github-cli/pkg/log"
+)
+
+func TestUpdateConfig(t *testing.T) {
+	const updateConfigFile = `[github]
+Organization = "hellofresh"
+Token = "env:GHCLI_TEST_TOKEN"
+
+[update]
+Channel = "prerelease"
+PublicKey = "~/.github-cli.asc"
+`
+
+	tests := []struct {
+		name     string
+		file     string
+		env      string
+		rootOpts RootOptions
+		token    string
+		update   config.Update
+	}{
+		{
+			name:   "configured",
+			file:   updateConfigFile,
+			env:    "ghp_config",
+			token:  "ghp_config",
+			update: config.Update{Channel: config.ChannelPrerelease, PublicKey: "~/.github-cli.asc"},
+		},
+		{
+			name:     "token flag",
+			file:     updateConfigFile,
+			env:      "ghp_config",
+			rootOpts: RootOptions{token: "ghp_flag"},
+			token:    "ghp_flag",
+			update:   config.Update{Channel: config.ChannelPrerelease, PublicKey: "~/.github-cli.asc"},
+		},
+		{
+			name:   "token that can not be resolved",
+			file:   updateConfigFile,
+			token:  "",
+			update: config.Update{Channel: config.ChannelPrerelease, PublicKey: "~/.github-cli.asc"},
+		},
+		{
+			name:  "configuration that can not be read",
+			file:  "[github\nOrganization = ",
+			env:   "ghp_config",
+			token: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GITHUB_TOKEN", "")
+			t.Setenv("GHCLI_TEST_TOKEN", tt.env)
+
+			tt.rootOpts.configFile = configFile(t, "config.toml", tt.file)
+			cfg, err := updateConfig(log.NewContext(context.Background()), &tt.rootOpts)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.token, cfg.Github.Token)
+			assert.Equal(t, tt.update, cfg.Update)
+		})
+	}
+}
+
+func TestUpdateConfigMissingFile(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "")
+
+	rootOpts := &RootOptions{configFile: filepath.Join(t.TempDir(), "missing.toml")}
+	cfg, err := updateConfig(log.NewContext(context.Background()), rootOpts)
+	require.NoError(t, err)
+	assert.Equal(t, config.Update{}, cfg.Update)
+}
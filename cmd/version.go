@@ -2,23 +2,115 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 
+	"github.com/hellofresh/github-cli/pkg/config"
 	"github.com/hellofresh/github-cli/pkg/log"
 )
 
-var version = "0.0.0-dev"
+// devVersion is the version of builds that were not released
+const devVersion = "0.0.0-dev"
+
+// defaultAPIURL is the API used when no GitHub Enterprise server is configured
+const defaultAPIURL = "https://api.github.com/"
+
+// Build metadata, set with -ldflags "-X github.com/hellofresh/github-cli/cmd.<name>=<value>" on release
+var (
+	version = devVersion
+	commit  = "unknown"
+	date    = "unknown"
+)
+
+type (
+	// VersionOpts are the flags for the version command
+	VersionOpts struct {
+		Output string
+	}
+
+	// versionInfo describes the running build
+	versionInfo struct {
+		Version   string `json:"version"`
+		Commit    string `json:"commit"`
+		Date      string `json:"date"`
+		GoVersion string `json:"go_version"`
+		OS        string `json:"os"`
+		Arch      string `json:"arch"`
+		APIURL    string `json:"api_url"`
+	}
+)
 
 // NewVersionCmd creates a new version command
-func NewVersionCmd(ctx context.Context) *cobra.Command {
-	return &cobra.Command{
-		Use:     "version",
-		Short:   "Print the version information",
-		Aliases: []string{"v"},
-		Run: func(cmd *cobra.Command, args []string) {
-			logger := log.WithContext(ctx)
-			logger.Infof("github-cli %s", version)
+func NewVersionCmd(ctx context.Context, rootOpts *RootOptions) *cobra.Command {
+	opts := &VersionOpts{}
+
+	cmd := &cobra.Command{
+		Use:         "version",
+		Short:       "Print the version information",
+		Long:        `Prints the version, git commit, build date, Go version and platform of the build, and the GitHub API it talks to`,
+		Aliases:     []string{"v"},
+		Annotations: map[string]string{skipConfigAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunVersion(ctx, cmd.OutOrStdout(), rootOpts, opts)
 		},
 	}
+
+	cmd.Flags().StringVar(&opts.Output, "output", "text", "Output format: text or json")
+
+	return cmd
+}
+
+// RunVersion runs the command to print the version information
+func RunVersion(ctx context.Context, out io.Writer, rootOpts *RootOptions, opts *VersionOpts) error {
+	if opts.Output != "text" && opts.Output != "json" {
+		return fmt.Errorf("invalid output %q, expected text or json", opts.Output)
+	}
+
+	info := &versionInfo{
+		Version:   version,
+		Commit:    commit,
+		Date:      date,
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		APIURL:    apiURL(ctx, rootOpts),
+	}
+
+	if opts.Output == "json" {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(info)
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "github-cli %s\n", info.Version)
+	fmt.Fprintf(w, "  Commit\t%s\n", info.Commit)
+	fmt.Fprintf(w, "  Built\t%s\n", info.Date)
+	fmt.Fprintf(w, "  Go\t%s\n", info.GoVersion)
+	fmt.Fprintf(w, "  Platform\t%s/%s\n", info.OS, info.Arch)
+	fmt.Fprintf(w, "  API\t%s\n", info.APIURL)
+
+	return w.Flush()
+}
+
+// apiURL returns the API the configuration points at. Only the file and the environment are read: the
+// includes and the tokens of the configuration may run commands, which printing the version must not do.
+// The version is printed even when the configuration can not be read.
+func apiURL(ctx context.Context, rootOpts *RootOptions) string {
+	baseURL, err := config.BaseURL(rootOpts.configFile, rootOpts.context)
+	if err != nil {
+		log.WithContext(ctx).WithError(err).Debug("Could not read the configuration")
+		return defaultAPIURL
+	}
+
+	if baseURL == "" {
+		return defaultAPIURL
+	}
+
+	return baseURL
 }
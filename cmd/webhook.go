@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+
+	"github.com/spf13/cobra"
+)
+
+// NewWebhookCmd aggregates the webhook commands
+func NewWebhookCmd(ctx context.Context) *cobra.Command {
+	// Webhook commands
+	cmd := &cobra.Command{
+		Use:     "webhook",
+		Aliases: []string{"webhooks"},
+		Short:   "Github repository webhooks management",
+	}
+
+	cmd.AddCommand(NewWebhookListCmd(ctx))
+	cmd.AddCommand(NewWebhookAddCmd(ctx))
+	cmd.AddCommand(NewWebhookRemoveCmd(ctx))
+	cmd.AddCommand(NewWebhookPingCmd(ctx))
+	cmd.AddCommand(NewWebhookRedeliverCmd(ctx))
+
+	return cmd
+}
+
+// webhookArgs validates the repository and webhook arguments
+func webhookArgs(cmd *cobra.Command, args []string) error {
+	if len(args) < 1 || args[0] == "" {
+		return errors.New("please provide a repository name")
+	}
+
+	if len(args) < 2 || args[1] == "" {
+		return errors.New("please provide the webhook ID or url")
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/go-github/v33/github"
+	"github.com/spf13/cobra"
+
+	"github.com/hellofresh/github-cli/pkg/config"
+	"github.com/hellofresh/github-cli/pkg/log"
+)
+
+// WebhookAddOpts are the flags for the add webhook command
+type WebhookAddOpts struct {
+	URL         string
+	ContentType string
+	Secret      string
+	Events      []string
+	InsecureSSL bool
+	Inactive    bool
+}
+
+// NewWebhookAddCmd creates a new add webhook command
+func NewWebhookAddCmd(ctx context.Context) *cobra.Command {
+	opts := &WebhookAddOpts{}
+
+	cmd := &cobra.Command{
+		Use:   "add [repo]",
+		Short: "Adds or updates webhooks of a repository",
+		Long: `Adds the webhook given with --url, or all the webhooks defined on your .github.toml.
+Webhooks that already exist with the same url are updated instead.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunWebhookAdd(ctx, args[0], opts)
+		},
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 || args[0] == "" {
+				return errors.New("please provide a repository name")
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.URL, "url", "", "The url the events are delivered to")
+	cmd.Flags().StringVar(&opts.ContentType, "content-type", "json", "The payload format, json or form")
+	cmd.Flags().StringVar(&opts.Secret, "secret", "", "The secret used to sign the payloads, or a reference to it such as env:NAME")
+	cmd.Flags().StringSliceVar(&opts.Events, "event", []string{"push"}, "The events triggering the webhook")
+	cmd.Flags().BoolVar(&opts.InsecureSSL, "insecure-ssl", false, "Skip verification of the SSL certificate of the url")
+	cmd.Flags().BoolVar(&opts.Inactive, "inactive", false, "Create the webhook without delivering events")
+
+	return cmd
+}
+
+// RunWebhookAdd runs the command to add webhooks to a repository
+func RunWebhookAdd(ctx context.Context, repoName string, opts *WebhookAddOpts) error {
+	log.AddField(ctx, log.FieldRepo, repoName)
+	logger := log.WithContext(ctx)
+	cfg := config.WithContext(ctx)
+
+	creator, org, err := newOrgRepo(ctx)
+	if err != nil {
+		return err
+	}
+
+	webhooks := cfg.Github.Webhooks
+	if opts.URL != "" {
+		hookConfig := map[string]interface{}{
+			"url":          opts.URL,
+			"content_type": opts.ContentType,
+			"insecure_ssl": opts.InsecureSSL,
+		}
+		if opts.Secret != "" {
+			hookConfig["secret"] = opts.Secret
+		}
+
+		webhooks = []*config.Webhook{{
+			Type:   "web",
+			Config: hookConfig,
+			Events: opts.Events,
+			Active: github.Bool(!opts.Inactive),
+		}}
+	}
+
+	if len(webhooks) == 0 {
+		return errors.New("no webhooks configured, please provide one with --url")
+	}
+
+	existing, err := creator.ListWebhooks(ctx, repoName, org)
+	if err != nil {
+		return fmt.Errorf("could not retrieve webhooks: %w", err)
+	}
+
+	for _, webhook := range webhooks {
+		hook, created, err := creator.UpsertWebhook(ctx, repoName, org, webhook, existing)
+		if err != nil {
+			return fmt.Errorf("could not add webhook %s: %w", webhook.URL(), err)
+		}
+
+		if created {
+			logger.Infof("Webhook %s created with ID %d", webhook.URL(), hook.GetID())
+		} else {
+			logger.Infof("Webhook %s updated", webhook.URL())
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v33/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hellofresh/github-cli/pkg/config"
+	"github.com/hellofresh/github-cli/pkg/repo"
+	"github.com/hellofresh/github-cli/pkg/test"
+)
+
+// fakeHook creates a webhook of a repository of the fake
+func fakeHook(id int64, url string, events ...string) *github.Hook {
+	return &github.Hook{
+		ID:     github.Int64(id),
+		Config: map[string]interface{}{"url": url, "content_type": "json"},
+		Events: events,
+		Active: github.Bool(true),
+	}
+}
+
+func TestWebhookAddE2E(t *testing.T) {
+	fake := test.NewFakeGithub()
+	// the existing webhooks are found across pages
+	fake.PerPage = 1
+	fake.AddOrg("hellofresh")
+
+	ctx := newFakeContext(t, fake, &config.Spec{Github: config.Github{
+		Organization: "hellofresh",
+		Webhooks: []*config.Webhook{
+			{Type: "web", Config: map[string]interface{}{"url": "https://ci.example.com/hook", "content_type": "form"}, Events: []string{"push", "pull_request"}},
+			{Type: "web", Config: map[string]interface{}{"url": "https://chat.example.com/hook", "content_type": "json"}, Events: []string{"release"}},
+		},
+	}})
+	r := fake.AddRepo("hellofresh", "svc", true)
+	r.Hooks = []*github.Hook{fakeHook(1, "https://other.example.com/hook", "push"), fakeHook(2, "https://ci.example.com/hook", "push")}
+
+	require.NoError(t, RunWebhookAdd(ctx, "svc", &WebhookAddOpts{}))
+
+	require.Len(t, r.Hooks, 3)
+	assert.Equal(t, "https://other.example.com/hook", r.Hooks[0].Config["url"])
+	assert.Equal(t, int64(2), r.Hooks[1].GetID(), "the webhook with the same url is updated")
+	assert.Equal(t, "form", r.Hooks[1].Config["content_type"])
+	assert.Equal(t, []string{"push", "pull_request"}, r.Hooks[1].Events)
+	assert.Equal(t, "https://chat.example.com/hook", r.Hooks[2].Config["url"])
+	assert.Equal(t, []string{"release"}, r.Hooks[2].Events)
+
+	require.NoError(t, RunWebhookAdd(ctx, "svc", &WebhookAddOpts{
+		URL:         "https://deploy.example.com/hook",
+		ContentType: "json",
+		Secret:      "s3cr3t",
+		Events:      []string{"deployment"},
+		Inactive:    true,
+	}))
+
+	require.Len(t, r.Hooks, 4)
+	assert.Equal(t, "https://deploy.example.com/hook", r.Hooks[3].Config["url"])
+	assert.Equal(t, "s3cr3t", r.Hooks[3].Config["secret"])
+	assert.False(t, r.Hooks[3].GetActive())
+}
+
+func TestWebhookAddE2ENothingConfigured(t *testing.T) {
+	fake := test.NewFakeGithub()
+	fake.AddOrg("hellofresh")
+
+	ctx := newFakeContext(t, fake, &config.Spec{Github: config.Github{Organization: "hellofresh"}})
+	fake.AddRepo("hellofresh", "svc", true)
+
+	assert.EqualError(t, RunWebhookAdd(ctx, "svc", &WebhookAddOpts{}), "no webhooks configured, please provide one with --url")
+}
+
+func TestWebhookListE2E(t *testing.T) {
+	fake := test.NewFakeGithub()
+	fake.PerPage = 1
+	fake.AddOrg("hellofresh")
+
+	ctx := newFakeContext(t, fake, &config.Spec{Github: config.Github{Organization: "hellofresh"}})
+	r := fake.AddRepo("hellofresh", "svc", true)
+	r.Hooks = []*github.Hook{fakeHook(1, "https://ci.example.com/hook", "push", "pull_request"), fakeHook(2, "https://chat.example.com/hook", "release")}
+	fake.AddDelivery("hellofresh", "svc", 1, http.StatusOK, "push")
+	fake.AddDelivery("hellofresh", "svc", 1, http.StatusBadGateway, "pull_request")
+
+	cmd := NewWebhookListCmd(ctx)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	require.NoError(t, RunWebhookList(ctx, cmd, "svc"))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, []string{"ID", "URL", "EVENTS", "CONTENT", "TYPE", "ACTIVE", "LAST", "DELIVERY"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"1", "https://ci.example.com/hook", "push,pull_request", "json", "true", "502", "Invalid", "HTTP", "Response:", "502"}, strings.Fields(lines[1])[:10])
+	assert.Equal(t, []string{"2", "https://chat.example.com/hook", "release", "json", "true", "-"}, strings.Fields(lines[2]))
+}
+
+func TestWebhookPingE2E(t *testing.T) {
+	fake := test.NewFakeGithub()
+	fake.AddOrg("hellofresh")
+
+	ctx := newFakeContext(t, fake, &config.Spec{Github: config.Github{Organization: "hellofresh"}})
+	r := fake.AddRepo("hellofresh", "svc", true)
+	r.Hooks = []*github.Hook{fakeHook(1, "https://ci.example.com/hook", "push")}
+
+	require.NoError(t, RunWebhookPing(ctx, "svc", "https://ci.example.com/hook"))
+	require.Len(t, r.Deliveries[1], 1)
+	assert.Equal(t, "ping", r.Deliveries[1][0]["event"])
+
+	err := RunWebhookPing(ctx, "svc", "https://missing.example.com/hook")
+	assert.True(t, errors.Is(err, repo.ErrWebhookNotFound))
+}
+
+func TestWebhookRedeliverE2E(t *testing.T) {
+	fake := test.NewFakeGithub()
+	fake.AddOrg("hellofresh")
+
+	ctx := newFakeContext(t, fake, &config.Spec{Github: config.Github{Organization: "hellofresh"}})
+	r := fake.AddRepo("hellofresh", "svc", true)
+	r.Hooks = []*github.Hook{fakeHook(1, "https://ci.example.com/hook", "push")}
+	fake.AddDelivery("hellofresh", "svc", 1, http.StatusOK, "push")
+	fake.AddDelivery("hellofresh", "svc", 1, http.StatusInternalServerError, "pull_request")
+	fake.AddDelivery("hellofresh", "svc", 1, http.StatusOK, "push")
+
+	require.NoError(t, RunWebhookRedeliver(ctx, "svc", "1", &WebhookRedeliverOpts{}))
+
+	deliveries := r.Deliveries[1]
+	require.Len(t, deliveries, 4)
+	assert.Equal(t, "pull_request", deliveries[3]["event"], "the most recent failed delivery is delivered again")
+	assert.Equal(t, true, deliveries[3]["redelivery"])
+
+	// a given delivery is delivered again even when it succeeded
+	delivery := deliveries[0]["id"].(int64)
+	require.NoError(t, RunWebhookRedeliver(ctx, "svc", "https://ci.example.com/hook", &WebhookRedeliverOpts{DeliveryID: delivery}))
+	require.Len(t, r.Deliveries[1], 5)
+	assert.Equal(t, "push", r.Deliveries[1][4]["event"])
+
+	r.Deliveries[1] = nil
+	err := RunWebhookRedeliver(ctx, "svc", "1", &WebhookRedeliverOpts{})
+	assert.True(t, errors.Is(err, repo.ErrHookDeliveryNotFound))
+}
+
+func TestWebhookRemoveE2E(t *testing.T) {
+	fake := test.NewFakeGithub()
+	fake.PerPage = 1
+	fake.AddOrg("hellofresh")
+
+	ctx := newFakeContext(t, fake, &config.Spec{Github: config.Github{Organization: "hellofresh"}})
+	r := fake.AddRepo("hellofresh", "svc", true)
+	r.Hooks = []*github.Hook{fakeHook(1, "https://ci.example.com/hook", "push"), fakeHook(2, "https://chat.example.com/hook", "release")}
+
+	require.NoError(t, RunWebhookRemove(ctx, "svc", "https://chat.example.com/hook"))
+
+	require.Len(t, r.Hooks, 1)
+	assert.Equal(t, int64(1), r.Hooks[0].GetID())
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hellofresh/github-cli/pkg/log"
+)
+
+// NewWebhookListCmd creates a new list webhooks command
+func NewWebhookListCmd(ctx context.Context) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list [repo]",
+		Short: "Lists the webhooks of a repository",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunWebhookList(ctx, cmd, args[0])
+		},
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 || args[0] == "" {
+				return errors.New("please provide a repository name")
+			}
+
+			return nil
+		},
+	}
+}
+
+// RunWebhookList runs the command to list the webhooks of a repository
+func RunWebhookList(ctx context.Context, cmd *cobra.Command, repoName string) error {
+	log.AddField(ctx, log.FieldRepo, repoName)
+	logger := log.WithContext(ctx)
+
+	creator, org, err := newOrgRepo(ctx)
+	if err != nil {
+		return err
+	}
+
+	hooks, err := creator.ListWebhooks(ctx, repoName, org)
+	if err != nil {
+		return fmt.Errorf("could not retrieve webhooks: %w", err)
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tURL\tEVENTS\tCONTENT TYPE\tACTIVE\tLAST DELIVERY")
+	for _, hook := range hooks {
+		lastDelivery := "-"
+		deliveries, err := creator.ListWebhookDeliveries(ctx, repoName, org, hook.GetID(), 1)
+		if err != nil {
+			logger.WithError(err).Debugf("Could not retrieve deliveries of webhook %d", hook.GetID())
+		} else if len(deliveries) > 0 {
+			d := deliveries[0]
+			lastDelivery = fmt.Sprintf("%d %s (%s)", d.StatusCode, d.Status, d.DeliveredAt.Format("2006-01-02 15:04"))
+		}
+
+		fmt.Fprintf(w, "%d\t%s\t%s\t%v\t%t\t%s\n",
+			hook.GetID(),
+			hook.Config["url"],
+			strings.Join(hook.Events, ","),
+			hook.Config["content_type"],
+			hook.GetActive(),
+			lastDelivery,
+		)
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hellofresh/github-cli/pkg/log"
+)
+
+// NewWebhookPingCmd creates a new ping webhook command
+func NewWebhookPingCmd(ctx context.Context) *cobra.Command {
+	return &cobra.Command{
+		Use:   "ping [repo] [id|url]",
+		Short: "Sends a ping event to a webhook",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunWebhookPing(ctx, args[0], args[1])
+		},
+		Args: webhookArgs,
+	}
+}
+
+// RunWebhookPing runs the command to ping a webhook
+func RunWebhookPing(ctx context.Context, repoName string, idOrURL string) error {
+	log.AddField(ctx, log.FieldRepo, repoName)
+	logger := log.WithContext(ctx)
+
+	creator, org, err := newOrgRepo(ctx)
+	if err != nil {
+		return err
+	}
+
+	hook, err := creator.FindWebhook(ctx, repoName, org, idOrURL)
+	if err != nil {
+		return err
+	}
+
+	if err := creator.PingWebhook(ctx, repoName, org, hook.GetID()); err != nil {
+		return fmt.Errorf("could not ping webhook: %w", err)
+	}
+
+	logger.Infof("Ping sent to webhook %d, check its deliveries with `github-cli webhook list %s`", hook.GetID(), repoName)
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hellofresh/github-cli/pkg/log"
+	"github.com/hellofresh/github-cli/pkg/repo"
+)
+
+// WebhookRedeliverOpts are the flags for the redeliver webhook command
+type WebhookRedeliverOpts struct {
+	DeliveryID int64
+}
+
+// NewWebhookRedeliverCmd creates a new redeliver webhook command
+func NewWebhookRedeliverCmd(ctx context.Context) *cobra.Command {
+	opts := &WebhookRedeliverOpts{}
+
+	cmd := &cobra.Command{
+		Use:   "redeliver [repo] [id|url]",
+		Short: "Delivers again a webhook event",
+		Long:  `Delivers again the delivery given with --delivery, or the most recent failed delivery of the webhook`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunWebhookRedeliver(ctx, args[0], args[1], opts)
+		},
+		Args: webhookArgs,
+	}
+
+	cmd.Flags().Int64Var(&opts.DeliveryID, "delivery", 0, "The ID of the delivery to redeliver")
+
+	return cmd
+}
+
+// RunWebhookRedeliver runs the command to redeliver a webhook event
+func RunWebhookRedeliver(ctx context.Context, repoName string, idOrURL string, opts *WebhookRedeliverOpts) error {
+	log.AddField(ctx, log.FieldRepo, repoName)
+	logger := log.WithContext(ctx)
+
+	creator, org, err := newOrgRepo(ctx)
+	if err != nil {
+		return err
+	}
+
+	hook, err := creator.FindWebhook(ctx, repoName, org, idOrURL)
+	if err != nil {
+		return err
+	}
+
+	deliveryID := opts.DeliveryID
+	if deliveryID == 0 {
+		deliveries, err := creator.ListWebhookDeliveries(ctx, repoName, org, hook.GetID(), 100)
+		if err != nil {
+			return fmt.Errorf("could not retrieve deliveries: %w", err)
+		}
+
+		for _, delivery := range deliveries {
+			if delivery.Failed() {
+				deliveryID = delivery.ID
+				logger.Debugf("Redelivering %s event from %s", delivery.Event, delivery.DeliveredAt)
+				break
+			}
+		}
+
+		if deliveryID == 0 {
+			return fmt.Errorf("%w: no recent failed delivery for webhook %d", repo.ErrHookDeliveryNotFound, hook.GetID())
+		}
+	}
+
+	if err := creator.RedeliverWebhook(ctx, repoName, org, hook.GetID(), deliveryID); err != nil {
+		return fmt.Errorf("could not redeliver webhook: %w", err)
+	}
+
+	logger.Infof("Delivery %d of webhook %d queued", deliveryID, hook.GetID())
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hellofresh/github-cli/pkg/log"
+)
+
+// NewWebhookRemoveCmd creates a new remove webhook command
+func NewWebhookRemoveCmd(ctx context.Context) *cobra.Command {
+	return &cobra.Command{
+		Use:     "remove [repo] [id|url]",
+		Aliases: []string{"rm"},
+		Short:   "Removes a webhook from a repository",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunWebhookRemove(ctx, args[0], args[1])
+		},
+		Args: webhookArgs,
+	}
+}
+
+// RunWebhookRemove runs the command to remove a webhook from a repository
+func RunWebhookRemove(ctx context.Context, repoName string, idOrURL string) error {
+	log.AddField(ctx, log.FieldRepo, repoName)
+	logger := log.WithContext(ctx)
+
+	creator, org, err := newOrgRepo(ctx)
+	if err != nil {
+		return err
+	}
+
+	hook, err := creator.FindWebhook(ctx, repoName, org, idOrURL)
+	if err != nil {
+		return err
+	}
+
+	if err := creator.RemoveWebhook(ctx, repoName, org, hook.GetID()); err != nil {
+		return fmt.Errorf("could not remove webhook: %w", err)
+	}
+
+	logger.Infof("Webhook %d removed from %s/%s", hook.GetID(), org, repoName)
+
+	return nil
+}
@@ -0,0 +1,349 @@
+// Package fixture records the interactions with the github API to fixture files and replays them,
+// so commands can be checked against real responses without calling github
+package fixture
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+type (
+	// Interaction is a request to the github API and the response it got, as stored in fixture files
+	Interaction struct {
+		Request  RecordedRequest  `json:"request"`
+		Response RecordedResponse `json:"response"`
+	}
+
+	// RecordedRequest is a recorded request, its path is relative to the API root
+	RecordedRequest struct {
+		Method string `json:"method"`
+		Path   string `json:"path"`
+		Body   string `json:"body,omitempty"`
+	}
+
+	// RecordedResponse is a recorded response, binary bodies are kept in BodyBase64
+	RecordedResponse struct {
+		Status     int         `json:"status"`
+		Header     http.Header `json:"header,omitempty"`
+		Body       string      `json:"body,omitempty"`
+		BodyBase64 string      `json:"body_base64,omitempty"`
+	}
+
+	// Recorder writes interactions to a fixture file, with the secrets scrubbed. The file is rewritten
+	// after each interaction, so it is complete whenever the process exits.
+	Recorder struct {
+		file string
+
+		mu           sync.Mutex
+		interactions []*Interaction
+	}
+
+	// recordingTransport is an http.RoundTripper recording its interactions
+	recordingTransport struct {
+		recorder  *Recorder
+		transport http.RoundTripper
+		secrets   []string
+	}
+
+	// Replayer is an http.RoundTripper answering with the interactions of a fixture file instead of
+	// calling github. Requests are answered in the recorded order, a request recorded several times,
+	// e.g. when polling, gets the responses in turn and then the last one again.
+	Replayer struct {
+		mu           sync.Mutex
+		interactions []*Interaction
+		used         []bool
+		missing      []string
+	}
+)
+
+// Scrubbed replaces the secrets in the recorded interactions
+const Scrubbed = "REDACTED"
+
+// scrubbedHeaders are the headers carrying credentials, they are never recorded
+var scrubbedHeaders = []string{"Authorization", "Cookie", "Set-Cookie", "X-Github-Sso"}
+
+// scrubbedFields are the keys of the JSON bodies holding secrets, e.g. the secret of a webhook
+var scrubbedFields = map[string]bool{"secret": true, "token": true}
+
+// enterprisePrefix is the root of the API on GitHub Enterprise servers, fixtures are stored without it
+const enterprisePrefix = "/api/v3"
+
+// NewRecorder creates a recorder writing to a fixture file
+func NewRecorder(file string) *Recorder {
+	return &Recorder{file: file}
+}
+
+// Wrap returns an http.RoundTripper sending the requests with transport, http.DefaultTransport when nil,
+// and recording them. Secrets, such as the tokens, are replaced wherever they appear in the interactions.
+func (r *Recorder) Wrap(transport http.RoundTripper, secrets ...string) http.RoundTripper {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	t := &recordingTransport{recorder: r, transport: transport}
+	for _, secret := range secrets {
+		if secret != "" {
+			t.secrets = append(t.secrets, secret)
+		}
+	}
+
+	return t
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		if reqBody, err = ioutil.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		req.Body = ioutil.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	interaction := &Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			Path:   r.scrub(strings.TrimPrefix(req.URL.RequestURI(), enterprisePrefix)),
+			Body:   r.scrubBody(reqBody),
+		},
+		Response: RecordedResponse{
+			Status: resp.StatusCode,
+			Header: r.scrubHeader(resp.Header),
+		},
+	}
+	if utf8.Valid(respBody) {
+		interaction.Response.Body = r.scrubBody(respBody)
+	} else {
+		interaction.Response.BodyBase64 = base64.StdEncoding.EncodeToString(respBody)
+	}
+
+	if err := r.recorder.add(interaction); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *Recorder) add(interaction *Interaction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.interactions = append(r.interactions, interaction)
+
+	return Save(r.file, r.interactions)
+}
+
+func (r *recordingTransport) scrub(s string) string {
+	for _, secret := range r.secrets {
+		s = strings.ReplaceAll(s, secret, Scrubbed)
+	}
+
+	return s
+}
+
+// scrubBody scrubs the secrets of a body, and the values of the secret fields when it is JSON
+func (r *recordingTransport) scrubBody(body []byte) string {
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err == nil && scrubFields(v) {
+		if b, err := json.Marshal(v); err == nil {
+			body = b
+		}
+	}
+
+	return r.scrub(string(body))
+}
+
+// scrubFields replaces the values of the secret fields found in a decoded JSON value and tells whether
+// any was replaced
+func scrubFields(v interface{}) bool {
+	var scrubbed bool
+
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if s, ok := value.(string); ok && scrubbedFields[strings.ToLower(key)] && s != "" {
+				v[key] = Scrubbed
+				scrubbed = true
+			} else if scrubFields(value) {
+				scrubbed = true
+			}
+		}
+	case []interface{}:
+		for _, value := range v {
+			if scrubFields(value) {
+				scrubbed = true
+			}
+		}
+	}
+
+	return scrubbed
+}
+
+func (r *recordingTransport) scrubHeader(header http.Header) http.Header {
+	result := make(http.Header, len(header))
+	for key, values := range header {
+		for _, value := range values {
+			result.Add(key, r.scrub(value))
+		}
+	}
+
+	for _, key := range scrubbedHeaders {
+		result.Del(key)
+	}
+	// the length changes with the scrubbed body
+	result.Del("Content-Length")
+
+	return result
+}
+
+// Load reads the interactions of a fixture file
+func Load(file string) ([]*Interaction, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("could not read fixtures: %w", err)
+	}
+
+	var interactions []*Interaction
+	if err := json.Unmarshal(b, &interactions); err != nil {
+		return nil, fmt.Errorf("could not parse fixtures %s: %w", file, err)
+	}
+
+	return interactions, nil
+}
+
+// Save writes interactions to a fixture file, creating its directory
+func Save(file string, interactions []*Interaction) error {
+	b, err := json.MarshalIndent(interactions, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not marshal fixtures: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		return fmt.Errorf("could not write fixtures: %w", err)
+	}
+
+	if err := ioutil.WriteFile(file, append(b, '\n'), 0600); err != nil {
+		return fmt.Errorf("could not write fixtures: %w", err)
+	}
+
+	return nil
+}
+
+// NewReplayer creates a replayer of the given interactions
+func NewReplayer(interactions []*Interaction) *Replayer {
+	return &Replayer{
+		interactions: interactions,
+		used:         make([]bool, len(interactions)),
+	}
+}
+
+// RoundTrip implements http.RoundTripper. Requests that were not recorded get a 404 and are reported by Missing.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	method := req.Method
+	path := strings.TrimPrefix(req.URL.RequestURI(), enterprisePrefix)
+
+	last := -1
+	for i, interaction := range r.interactions {
+		if interaction.Request.Method != method || interaction.Request.Path != path {
+			continue
+		}
+
+		last = i
+		if !r.used[i] {
+			r.used[i] = true
+			return interaction.Response.toResponse(req), nil
+		}
+	}
+
+	if last >= 0 {
+		return r.interactions[last].Response.toResponse(req), nil
+	}
+
+	r.missing = append(r.missing, method+" "+path)
+
+	body, _ := json.Marshal(map[string]string{"message": fmt.Sprintf("no recorded response for %s %s", method, path)})
+	notFound := RecordedResponse{
+		Status: http.StatusNotFound,
+		Header: http.Header{"Content-Type": []string{"application/json; charset=utf-8"}},
+		Body:   string(body),
+	}
+
+	return notFound.toResponse(req), nil
+}
+
+// Missing returns the requests that had no recorded response, as "METHOD /path"
+func (r *Replayer) Missing() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]string{}, r.missing...)
+}
+
+// Unused returns the recorded requests that were not replayed, as "METHOD /path"
+func (r *Replayer) Unused() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var unused []string
+	for i, interaction := range r.interactions {
+		if !r.used[i] {
+			unused = append(unused, interaction.Request.Method+" "+interaction.Request.Path)
+		}
+	}
+
+	return unused
+}
+
+func (r RecordedResponse) toResponse(req *http.Request) *http.Response {
+	body := []byte(r.Body)
+	if r.BodyBase64 != "" {
+		body, _ = base64.StdEncoding.DecodeString(r.BodyBase64)
+	}
+
+	header := r.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.Status, http.StatusText(r.Status)),
+		StatusCode:    r.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,136 @@
+package fixture
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorderScrubsSecrets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Set-Cookie", "session=ghp_token")
+		w.Header().Set("X-Echo", "ghp_token")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":1,"config":{"url":"https://ci.example.com","secret":"hook-secret"},"owner":"ghp_other"}`))
+	}))
+	defer server.Close()
+
+	file := filepath.Join(t.TempDir(), "fixtures", "hooks.json")
+	client := &http.Client{Transport: NewRecorder(file).Wrap(nil, "ghp_token", "", "ghp_other")}
+
+	body := `{"name":"web","config":{"url":"https://ci.example.com","secret":"hook-secret"},"events":["push"]}`
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/api/v3/repos/hellofresh/svc/hooks?access_token=ghp_token", strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "token ghp_token")
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "hook-secret", "the response is not changed")
+
+	raw, err := ioutil.ReadFile(file)
+	require.NoError(t, err)
+	for _, secret := range []string{"ghp_token", "ghp_other", "hook-secret"} {
+		assert.NotContains(t, string(raw), secret)
+	}
+
+	interactions, err := Load(file)
+	require.NoError(t, err)
+	require.Len(t, interactions, 1)
+
+	recorded := interactions[0]
+	assert.Equal(t, RecordedRequest{
+		Method: http.MethodPost,
+		Path:   "/repos/hellofresh/svc/hooks?access_token=REDACTED",
+		Body:   `{"config":{"secret":"REDACTED","url":"https://ci.example.com"},"events":["push"],"name":"web"}`,
+	}, recorded.Request)
+	assert.Equal(t, http.StatusCreated, recorded.Response.Status)
+	assert.Equal(t, `{"config":{"secret":"REDACTED","url":"https://ci.example.com"},"id":1,"owner":"REDACTED"}`, recorded.Response.Body)
+	assert.Equal(t, "REDACTED", recorded.Response.Header.Get("X-Echo"))
+	assert.Empty(t, recorded.Response.Header.Get("Set-Cookie"))
+	assert.Empty(t, recorded.Response.Header.Get("Content-Length"))
+}
+
+func TestRecorderKeepsBodiesWithoutSecrets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte{0xff, 0x00, 0x01})
+	}))
+	defer server.Close()
+
+	file := filepath.Join(t.TempDir(), "git.json")
+	client := &http.Client{Transport: NewRecorder(file).Wrap(nil)}
+
+	body := `{"name": "svc",  "private": true}`
+	resp, err := client.Post(server.URL+"/orgs/hellofresh/repos", "application/json", strings.NewReader(body))
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	interactions, err := Load(file)
+	require.NoError(t, err)
+	require.Len(t, interactions, 1)
+	assert.Equal(t, body, interactions[0].Request.Body, "bodies without secret fields are kept as sent")
+	assert.Empty(t, interactions[0].Response.Body)
+	assert.Equal(t, "/wAB", interactions[0].Response.BodyBase64)
+}
+
+func TestReplayer(t *testing.T) {
+	interactions := []*Interaction{
+		{Request: RecordedRequest{Method: "GET", Path: "/repos/hellofresh/svc/branches/main"}, Response: RecordedResponse{Status: http.StatusNotFound, Body: `{"message":"Branch not found"}`}},
+		{Request: RecordedRequest{Method: "GET", Path: "/repos/hellofresh/svc/branches/main"}, Response: RecordedResponse{Status: http.StatusOK, Body: `{"name":"main"}`}},
+		{Request: RecordedRequest{Method: "DELETE", Path: "/repos/hellofresh/svc"}, Response: RecordedResponse{Status: http.StatusNoContent}},
+	}
+	replayer := NewReplayer(interactions)
+	client := &http.Client{Transport: replayer}
+
+	get := func(url string) (int, string) {
+		t.Helper()
+
+		resp, err := client.Get(url)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		b, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		return resp.StatusCode, string(b)
+	}
+
+	status, body := get("https://api.github.com/repos/hellofresh/svc/branches/main")
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Equal(t, `{"message":"Branch not found"}`, body)
+
+	// enterprise servers serve the API under /api/v3
+	status, body = get("https://github.example.com/api/v3/repos/hellofresh/svc/branches/main")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, `{"name":"main"}`, body)
+
+	status, _ = get("https://api.github.com/repos/hellofresh/svc/branches/main")
+	assert.Equal(t, http.StatusOK, status, "the last response is repeated")
+
+	status, _ = get("https://api.github.com/repos/hellofresh/other")
+	assert.Equal(t, http.StatusNotFound, status)
+
+	assert.Equal(t, []string{"GET /repos/hellofresh/other"}, replayer.Missing())
+	assert.Equal(t, []string{"DELETE /repos/hellofresh/svc"}, replayer.Unused())
+}
+
+func TestLoadInvalidFixtures(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "invalid.json")
+	require.NoError(t, ioutil.WriteFile(file, []byte("{"), 0600))
+
+	_, err := Load(file)
+	assert.Error(t, err)
+
+	_, err = Load(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,163 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hellofresh/github-cli/pkg/config"
+)
+
+type (
+	// Entry is a mutating call to the github API, one line of the audit log
+	Entry struct {
+		Time time.Time `json:"time"`
+		// User is the login of the owner of the token
+		User    string `json:"user"`
+		Command string `json:"command"`
+		Org     string `json:"org,omitempty"`
+		Repo    string `json:"repo,omitempty"`
+		Action  string `json:"action"`
+		Method  string `json:"method"`
+		Path    string `json:"path"`
+		Status  int    `json:"status,omitempty"`
+		Outcome string `json:"outcome"`
+		Error   string `json:"error,omitempty"`
+	}
+
+	// Log appends entries to a JSON lines file
+	Log struct {
+		file string
+		mu   sync.Mutex
+	}
+
+	action struct {
+		method string
+		path   *regexp.Regexp
+		name   string
+	}
+)
+
+const (
+	// OutcomeSuccess is used when github accepted the call
+	OutcomeSuccess = "success"
+	// OutcomeFailure is used when the call failed or github rejected it
+	OutcomeFailure = "failure"
+)
+
+// actions name the calls github-cli makes, after github's own audit log actions
+var actions = []action{
+	{"POST", regexp.MustCompile(`^/orgs/[^/]+/repos$`), "repo.create"},
+	{"PATCH", regexp.MustCompile(`^/repos/[^/]+/[^/]+$`), "repo.update"},
+	{"DELETE", regexp.MustCompile(`^/repos/[^/]+/[^/]+$`), "repo.destroy"},
+	{"POST", regexp.MustCompile(`^/repos/[^/]+/[^/]+/transfer$`), "repo.transfer"},
+	{"PUT", regexp.MustCompile(`^/repos/[^/]+/[^/]+/topics$`), "repo.update_topics"},
+	{"PUT", regexp.MustCompile(`^/repos/[^/]+/[^/]+/vulnerability-alerts$`), "repo.enable_vulnerability_alerts"},
+	{"DELETE", regexp.MustCompile(`^/repos/[^/]+/[^/]+/vulnerability-alerts$`), "repo.disable_vulnerability_alerts"},
+	{"PUT", regexp.MustCompile(`^/repos/[^/]+/[^/]+/automated-security-fixes$`), "repo.enable_security_fixes"},
+	{"DELETE", regexp.MustCompile(`^/repos/[^/]+/[^/]+/automated-security-fixes$`), "repo.disable_security_fixes"},
+	{"POST", regexp.MustCompile(`^/repos/[^/]+/[^/]+/pages$`), "repo.pages_create"},
+	{"DELETE", regexp.MustCompile(`^/repos/[^/]+/[^/]+/pages$`), "repo.pages_destroy"},
+	{"POST", regexp.MustCompile(`^/repos/[^/]+/[^/]+/git/refs$`), "branch.create"},
+	{"POST", regexp.MustCompile(`^/repos/[^/]+/[^/]+/branches/[^/]+/rename$`), "branch.rename"},
+	{"PUT", regexp.MustCompile(`^/repos/[^/]+/[^/]+/branches/[^/]+/protection$`), "protected_branch.update"},
+	{"DELETE", regexp.MustCompile(`^/repos/[^/]+/[^/]+/branches/[^/]+/protection$`), "protected_branch.destroy"},
+	{"PUT", regexp.MustCompile(`^/organizations/[^/]+/team/[^/]+/repos/[^/]+/[^/]+$`), "team.add_repository"},
+	{"PUT", regexp.MustCompile(`^/repos/[^/]+/[^/]+/collaborators/[^/]+$`), "repo.add_member"},
+	{"DELETE", regexp.MustCompile(`^/repos/[^/]+/[^/]+/collaborators/[^/]+$`), "repo.remove_member"},
+	{"PATCH", regexp.MustCompile(`^/repos/[^/]+/[^/]+/invitations/[^/]+$`), "repo.update_invitation"},
+	{"POST", regexp.MustCompile(`^/repos/[^/]+/[^/]+/labels$`), "label.create"},
+	{"PATCH", regexp.MustCompile(`^/repos/[^/]+/[^/]+/labels/[^/]+$`), "label.update"},
+	{"DELETE", regexp.MustCompile(`^/repos/[^/]+/[^/]+/labels/[^/]+$`), "label.destroy"},
+	{"POST", regexp.MustCompile(`^/repos/[^/]+/[^/]+/hooks$`), "hook.create"},
+	{"PATCH", regexp.MustCompile(`^/repos/[^/]+/[^/]+/hooks/[^/]+$`), "hook.update"},
+	{"DELETE", regexp.MustCompile(`^/repos/[^/]+/[^/]+/hooks/[^/]+$`), "hook.destroy"},
+	{"POST", regexp.MustCompile(`^/repos/[^/]+/[^/]+/hooks/[^/]+/pings$`), "hook.ping"},
+	{"POST", regexp.MustCompile(`^/repos/[^/]+/[^/]+/hooks/[^/]+/deliveries/[^/]+/attempts$`), "hook.redeliver"},
+}
+
+// DefaultFile returns where the audit log is kept when none is configured:
+// audit.jsonl in the state directory, see config.StateDir
+func DefaultFile() (string, error) {
+	dir, err := config.StateDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "audit.jsonl"), nil
+}
+
+// NewLog creates an audit log appending to file
+func NewLog(file string) *Log {
+	return &Log{file: file}
+}
+
+// Append writes an entry at the end of the log, the file is only ever appended to
+func (l *Log) Append(entry *Entry) error {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("could not marshal audit entry: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(l.file), 0700); err != nil {
+		return fmt.Errorf("could not write audit log: %w", err)
+	}
+
+	f, err := os.OpenFile(l.file, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("could not write audit log: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		return fmt.Errorf("could not write audit log: %w", err)
+	}
+
+	return nil
+}
+
+// Mutating checks if a request changes something and belongs in the audit log
+func Mutating(method string) bool {
+	switch method {
+	case "GET", "HEAD", "OPTIONS":
+		return false
+	}
+
+	return true
+}
+
+// Action names a call to the API, path is relative to the API root. Unknown calls are named after
+// their method and path.
+func Action(method string, path string) string {
+	for _, a := range actions {
+		if a.method == method && a.path.MatchString(path) {
+			return a.name
+		}
+	}
+
+	return method + " " + path
+}
+
+// Target returns the organization and repository a call to the API is about, path is relative to the API root
+func Target(path string) (org string, repo string) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+
+	switch {
+	case len(parts) >= 3 && parts[0] == "repos":
+		return parts[1], parts[2]
+	case len(parts) >= 7 && parts[0] == "organizations" && parts[4] == "repos":
+		return parts[5], parts[6]
+	case len(parts) >= 2 && parts[0] == "orgs":
+		return parts[1], ""
+	}
+
+	return "", ""
+}
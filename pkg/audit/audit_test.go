@@ -0,0 +1,106 @@
+package audit
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAction(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		action string
+	}{
+		{"POST", "/orgs/hellofresh/repos", "repo.create"},
+		{"PATCH", "/repos/hellofresh/svc", "repo.update"},
+		{"DELETE", "/repos/hellofresh/svc", "repo.destroy"},
+		{"POST", "/repos/hellofresh/svc/transfer", "repo.transfer"},
+		{"PUT", "/repos/hellofresh/svc/topics", "repo.update_topics"},
+		{"POST", "/repos/hellofresh/svc/git/refs", "branch.create"},
+		{"POST", "/repos/hellofresh/svc/branches/master/rename", "branch.rename"},
+		{"PUT", "/repos/hellofresh/svc/branches/main/protection", "protected_branch.update"},
+		{"DELETE", "/repos/hellofresh/svc/branches/main/protection", "protected_branch.destroy"},
+		{"PUT", "/organizations/1/team/2/repos/hellofresh/svc", "team.add_repository"},
+		{"PUT", "/repos/hellofresh/svc/collaborators/alice", "repo.add_member"},
+		{"PATCH", "/repos/hellofresh/svc/invitations/1", "repo.update_invitation"},
+		{"POST", "/repos/hellofresh/svc/labels", "label.create"},
+		{"PATCH", "/repos/hellofresh/svc/labels/bug", "label.update"},
+		{"DELETE", "/repos/hellofresh/svc/labels/bug", "label.destroy"},
+		{"POST", "/repos/hellofresh/svc/hooks", "hook.create"},
+		{"POST", "/repos/hellofresh/svc/hooks/1/pings", "hook.ping"},
+		{"POST", "/repos/hellofresh/svc/hooks/1/deliveries/2/attempts", "hook.redeliver"},
+		// the method and the whole path must match
+		{"PUT", "/repos/hellofresh/svc/labels", "PUT /repos/hellofresh/svc/labels"},
+		{"DELETE", "/repos/hellofresh/svc/git/refs/heads/main", "DELETE /repos/hellofresh/svc/git/refs/heads/main"},
+		{"POST", "/user/repos", "POST /user/repos"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.action, Action(tt.method, tt.path))
+		})
+	}
+}
+
+func TestTarget(t *testing.T) {
+	tests := []struct {
+		path string
+		org  string
+		repo string
+	}{
+		{"/repos/hellofresh/svc", "hellofresh", "svc"},
+		{"/repos/hellofresh/svc/labels/bug", "hellofresh", "svc"},
+		{"/organizations/1/team/2/repos/hellofresh/svc", "hellofresh", "svc"},
+		{"/organizations/1/team/2", "", ""},
+		{"/orgs/hellofresh/repos", "hellofresh", ""},
+		{"/repos/hellofresh", "", ""},
+		{"/user/repos", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			org, repo := Target(tt.path)
+			assert.Equal(t, tt.org, org)
+			assert.Equal(t, tt.repo, repo)
+		})
+	}
+}
+
+func TestMutating(t *testing.T) {
+	for _, method := range []string{"GET", "HEAD", "OPTIONS"} {
+		assert.False(t, Mutating(method), method)
+	}
+	for _, method := range []string{"POST", "PUT", "PATCH", "DELETE"} {
+		assert.True(t, Mutating(method), method)
+	}
+}
+
+func TestLogAppend(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "github-cli", "audit.jsonl")
+	l := NewLog(file)
+
+	require.NoError(t, l.Append(&Entry{User: "alice", Action: "repo.create", Outcome: OutcomeSuccess}))
+	require.NoError(t, l.Append(&Entry{User: "alice", Action: "repo.destroy", Outcome: OutcomeFailure, Error: "Not Found"}))
+
+	b, err := ioutil.ReadFile(file)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(string(b), "\n"), "\n")
+	require.Len(t, lines, 2)
+
+	var entry Entry
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &entry))
+	assert.Equal(t, "repo.destroy", entry.Action)
+	assert.Equal(t, "Not Found", entry.Error)
+
+	info, err := os.Stat(file)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
@@ -0,0 +1,60 @@
+// Package cache keeps short-lived values on disk, such as the shell completion suggestions
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Cache stores JSON encoded values in a directory, one file per key
+type Cache struct {
+	dir string
+	ttl time.Duration
+}
+
+// New creates a cache in dir whose values expire after ttl
+func New(dir string, ttl time.Duration) *Cache {
+	return &Cache{dir: dir, ttl: ttl}
+}
+
+// Get decodes the value stored for key into v, it reports false when there is none or it expired
+func (c *Cache) Get(key string, v interface{}) bool {
+	file := c.file(key)
+
+	info, err := os.Stat(file)
+	if err != nil || time.Since(info.ModTime()) > c.ttl {
+		return false
+	}
+
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return false
+	}
+
+	return json.Unmarshal(b, v) == nil
+}
+
+// Set stores v for key
+func (c *Cache) Set(key string, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(c.file(key), b, 0600)
+}
+
+// file names the file of a key by its hash, keys can hold any character
+func (c *Cache) file(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
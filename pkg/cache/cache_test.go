@@ -0,0 +1,55 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "completion")
+	c := New(dir, time.Minute)
+
+	var names []string
+	assert.False(t, c.Get("repos hellofresh", &names), "nothing is cached yet")
+
+	require.NoError(t, c.Set("repos hellofresh", []string{"svc-a", "svc-b"}))
+	require.NoError(t, c.Set("repos https://github.example.com/api/v3/ hellofresh", []string{"svc-c"}))
+
+	assert.True(t, c.Get("repos hellofresh", &names))
+	assert.Equal(t, []string{"svc-a", "svc-b"}, names)
+	assert.True(t, c.Get("repos https://github.example.com/api/v3/ hellofresh", &names))
+	assert.Equal(t, []string{"svc-c"}, names)
+
+	info, err := os.Stat(dir)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0700), info.Mode().Perm())
+}
+
+func TestCacheExpiry(t *testing.T) {
+	c := New(t.TempDir(), time.Minute)
+	require.NoError(t, c.Set("teams hellofresh", []string{"platform"}))
+
+	var names []string
+	assert.True(t, c.Get("teams hellofresh", &names))
+
+	old := time.Now().Add(-2 * time.Minute)
+	require.NoError(t, os.Chtimes(c.file("teams hellofresh"), old, old))
+	assert.False(t, c.Get("teams hellofresh", &names), "the value expired")
+
+	require.NoError(t, c.Set("teams hellofresh", []string{"platform", "qa"}))
+	assert.True(t, c.Get("teams hellofresh", &names), "setting the value again renews it")
+	assert.Equal(t, []string{"platform", "qa"}, names)
+}
+
+func TestCacheInvalidValue(t *testing.T) {
+	c := New(t.TempDir(), time.Minute)
+	require.NoError(t, c.Set("teams hellofresh", map[string]int{"platform": 1}))
+
+	var names []string
+	assert.False(t, c.Get("teams hellofresh", &names), "a value of another type is a miss")
+}
@@ -2,10 +2,15 @@ package config
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 
 	homedir "github.com/mitchellh/go-homedir"
+	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/viper"
 
 	"github.com/hellofresh/github-cli/pkg/log"
@@ -16,23 +21,76 @@ type (
 
 	// Spec represents the global app configuration
 	Spec struct {
+		// Include lists configuration files merged below this one, see mergeSettings
+		Include       []string
 		Github        Github
 		GithubTestOrg Github
+		// Contexts are named organizations, the values set by the selected one replace the ones of Github,
+		// see UseContext
+		Contexts map[string]*Github
+		// CurrentContext is the context used when none is given with --context
+		CurrentContext string
+		// AuditLog is the file the mutating API calls are appended to, see audit.DefaultFile
+		AuditLog string
+		// Update configures the update command
+		Update Update
+
+		// context is the name of the selected context
+		context string
+		// file is the configuration file the spec was read from
+		file string
+		// unknown holds the keys found in the file that do not map to any field
+		unknown []string
+		// sources holds where each of the values was set
+		sources map[string]Source
+		// origins holds the file each of the included values comes from
+		origins map[string]string
+	}
+
+	// Update represents the update configuration
+	Update struct {
+		// Channel is the kind of releases updated to: stable, the default, or prerelease
+		Channel string
+		// PublicKey is an armored OpenPGP public key file, releases must be signed with it when set
+		PublicKey string
 	}
 
 	// Github represents the github configurations
 	Github struct {
-		Organization  string
-		Token         string
+		Organization string
+		Token        string
+		// BaseURL is the API endpoint of a GitHub Enterprise server
+		BaseURL       string
 		Teams         []*Team
 		Collaborators []*Collaborator
 		Labels        []*Label
 		Webhooks      []*Webhook
 		Protections   BranchProtections
-		// RemoveDefaultLabels Remove GitHub's default labels?
+		Settings      RepositorySettings
+		// RemoveDefaultLabels removes GitHub's default labels that are not configured
 		RemoveDefaultLabels bool
 	}
 
+	// RepositorySettings are the settings of the repositories, unset values are left as github has them
+	RepositorySettings struct {
+		// Visibility is public, private or internal, it takes precedence over the private flag
+		Visibility          string
+		Homepage            string
+		Topics              []string
+		DefaultBranch       string
+		AllowMergeCommit    *bool
+		AllowSquashMerge    *bool
+		AllowRebaseMerge    *bool
+		AllowAutoMerge      *bool
+		DeleteBranchOnMerge *bool
+		HasProjects         *bool
+		HasDiscussions      *bool
+		// VulnerabilityAlerts enables the Dependabot alerts, required by DependabotSecurityUpdates
+		VulnerabilityAlerts       *bool
+		DependabotSecurityUpdates *bool
+		SecretScanning            *bool
+	}
+
 	// BranchProtections represents github's branch protections
 	BranchProtections map[string][]string
 
@@ -50,57 +108,184 @@ type (
 
 	// Label represents a github label
 	Label struct {
-		Name  string
-		Color string
+		Name        string
+		Color       string
+		Description string
+		// Aliases are previous names of the label, a label with one of them is renamed keeping its issues
+		Aliases []string
 	}
 
 	// Webhook represents a github webhook
 	Webhook struct {
-		Type   string
+		// Type must be "web", the only kind of webhook github supports
+		Type string
+		// Config holds url, content_type (json or form), secret and insecure_ssl. The secret can be
+		// a reference, see ResolveSecret.
 		Config map[string]interface{}
+		// Events triggering the webhook, github defaults to push
+		Events []string
+		// Active defaults to true
+		Active *bool
 	}
 )
 
 const configKey configKeyType = iota
 
-// NewContext loads a configuration file into the Spec struct
-func NewContext(ctx context.Context, configFile string) (context.Context, error) {
+// Update channels
+const (
+	ChannelStable     = "stable"
+	ChannelPrerelease = "prerelease"
+)
+
+// NewContext returns a context holding an empty configuration. The configuration is populated
+// by Load once the command line flags are known.
+func NewContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, configKey, &Spec{})
+}
+
+// Load reads a configuration file into the Spec held by the context and resolves its tokens
+func Load(ctx context.Context, configFile string) error {
+	config := WithContext(ctx)
+	if config == nil {
+		return errors.New("no configuration found in context")
+	}
+
+	loaded, err := Read(ctx, configFile)
+	if err != nil {
+		return err
+	}
+
+	if err := loaded.ResolveTokens(ctx); err != nil {
+		return err
+	}
+
+	*config = *loaded
+
+	return nil
+}
+
+// Read loads a configuration file, and the files it includes, into a new Spec. Token references
+// are kept until ResolveTokens is called.
+func Read(ctx context.Context, configFile string) (*Spec, error) {
 	logger := log.WithContext(ctx)
+	v := viper.New()
 
 	if configFile != "" {
 		if _, err := os.Stat(configFile); os.IsNotExist(err) {
-			return ctx, fmt.Errorf("invalid configuration file provided %s", configFile)
+			return nil, fmt.Errorf("invalid configuration file provided %s", configFile)
+		}
+		if _, err := FormatOf(configFile); err != nil {
+			return nil, err
 		}
-		// Use config file from the flag.
-		viper.SetConfigFile(configFile)
 	} else {
 		homeDir, err := homedir.Dir()
 		if err != nil {
-			return ctx, err
+			return nil, err
 		}
 
-		viper.SetConfigName(".github")
-		viper.AddConfigPath(".")
-		viper.AddConfigPath(homeDir)
+		configFile = Search(homeDir)
 	}
 
-	logger.Debugf("Reading config from %s...", viper.ConfigFileUsed())
+	// Without a configuration file everything can still be set with environment variables
+	if configFile == "" {
+		logger.Debug("No configuration file found, using environment variables only")
+	} else {
+		logger.Debugf("Reading config from %s...", configFile)
+
+		v.SetConfigFile(configFile)
+		if err := v.ReadInConfig(); err != nil {
+			return nil, fmt.Errorf("could not read configurations: %w", err)
+		}
+	}
 
-	viper.SetDefault("github.token", os.Getenv("GITHUB_TOKEN"))
-	viper.SetDefault("githubtestorg.token", os.Getenv("GITHUB_TOKEN"))
+	config := Spec{file: v.ConfigFileUsed()}
+	own := v.AllSettings()
 
-	err := viper.ReadInConfig()
+	loader := newIncludeLoader(ctx, own)
+	settings, err := loader.loadAll(v.GetStringSlice("include"), filepath.Dir(config.file))
 	if err != nil {
-		return ctx, fmt.Errorf("could not read configurations: %w", err)
+		return nil, err
 	}
+	mergeSettings(settings, own)
 
-	config := Spec{}
-	err = viper.Unmarshal(&config)
+	merged := viper.New()
+	merged.SetDefault("github.token", os.Getenv("GITHUB_TOKEN"))
+	merged.SetDefault("githubtestorg.token", os.Getenv("GITHUB_TOKEN"))
+	if err := merged.MergeConfigMap(settings); err != nil {
+		return nil, fmt.Errorf("could not merge included configurations: %w", err)
+	}
+
+	var metadata mapstructure.Metadata
+	err = merged.Unmarshal(&config, func(c *mapstructure.DecoderConfig) {
+		c.Metadata = &metadata
+	})
 	if err != nil {
-		return ctx, fmt.Errorf("could not unmarshal config file: %w", err)
+		return nil, fmt.Errorf("could not unmarshal config file: %w", err)
+	}
+
+	for _, key := range metadata.Unused {
+		config.unknown = append(config.unknown, strings.ToLower(key))
 	}
 
-	return context.WithValue(ctx, configKey, &config), nil
+	for _, key := range merged.AllKeys() {
+		if !merged.InConfig(key) {
+			continue
+		}
+
+		source, origin := SourceFile, config.file
+		if !v.InConfig(key) {
+			source, origin = SourceInclude, loader.origin(key)
+		}
+
+		// maps such as Protections are flattened by viper, the parent key is set as well.
+		// A parent partially set by the file is reported as coming from the file.
+		for path := strings.Split(key, "."); len(path) > 0; path = path[:len(path)-1] {
+			parent := strings.Join(path, ".")
+			if source == SourceInclude && config.Source(parent) == SourceFile {
+				continue
+			}
+
+			config.setOrigin(parent, source, origin)
+		}
+	}
+
+	if os.Getenv("GITHUB_TOKEN") != "" {
+		for _, key := range []string{"github.token", "githubtestorg.token"} {
+			if !merged.InConfig(key) {
+				config.SetSource(key, SourceEnv)
+			}
+		}
+	}
+
+	if err := config.applyEnv(); err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
+
+// URL returns the url the webhook delivers to, which identifies it
+func (w *Webhook) URL() string {
+	url, _ := w.Config["url"].(string)
+	return url
+}
+
+// Override returns the settings with the values set in other taking precedence
+func (r RepositorySettings) Override(other RepositorySettings) RepositorySettings {
+	result := r
+	values, overrides := reflect.ValueOf(&result).Elem(), reflect.ValueOf(other)
+	for i := 0; i < overrides.NumField(); i++ {
+		if !overrides.Field(i).IsZero() {
+			values.Field(i).Set(overrides.Field(i))
+		}
+	}
+
+	return result
+}
+
+// File returns the path of the configuration file the spec was read from
+func (s *Spec) File() string {
+	return s.file
 }
 
 // WithContext returns a logrus logger from the context
@@ -0,0 +1,135 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/spf13/viper"
+)
+
+// UseContext makes the named context the organization the commands work on. The values the context
+// sets replace the ones of Github, the others, such as a token from GITHUB_TOKEN, are kept. Teams are
+// only kept for a context of the same organization, as their IDs are specific to it.
+func (s *Spec) UseContext(ctx context.Context, name string) error {
+	name = strings.ToLower(name)
+
+	gh, ok := s.Contexts[name]
+	if !ok || gh == nil {
+		return fmt.Errorf("context %q not found, available contexts: %s", name, strings.Join(s.ContextNames(), ", "))
+	}
+
+	prefix := "contexts." + name
+	token, err := s.resolveToken(ctx, prefix+".token", gh.Token)
+	if err != nil {
+		return err
+	}
+
+	otherOrg := gh.Organization != "" && !strings.EqualFold(gh.Organization, s.Github.Organization)
+
+	fields := make(map[string]reflect.Value)
+	walk("github", reflect.ValueOf(gh).Elem(), func(key string, field reflect.Value) {
+		fields[key] = field
+	})
+
+	walk("github", reflect.ValueOf(&s.Github).Elem(), func(key string, field reflect.Value) {
+		contextKey := prefix + strings.TrimPrefix(key, "github")
+		value := fields[key]
+		if s.Source(contextKey) == SourceDefault && value.IsZero() && !(otherOrg && key == "github.teams") {
+			return
+		}
+
+		field.Set(value)
+		s.SetSource(key, s.Source(contextKey))
+	})
+
+	if gh.Token != "" {
+		s.Github.Token = token
+	}
+	s.context = name
+
+	return nil
+}
+
+// Context returns the name of the selected context, empty when Github is used as it is
+func (s *Spec) Context() string {
+	return s.context
+}
+
+// ContextNames returns the sorted names of all the contexts
+func (s *Spec) ContextNames() []string {
+	names := make([]string, 0, len(s.Contexts))
+	for name := range s.Contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Organization returns the configuration of an organization: Github when it is the one the commands
+// work on, otherwise the context defined for it
+func (s *Spec) Organization(name string) (*Github, bool) {
+	if strings.EqualFold(s.Github.Organization, name) {
+		return &s.Github, true
+	}
+
+	for _, contextName := range s.ContextNames() {
+		if gh := s.Contexts[contextName]; gh != nil && strings.EqualFold(gh.Organization, name) {
+			return gh, true
+		}
+	}
+
+	return nil, false
+}
+
+// BaseURL returns the API endpoint of the named context, the current one when name is empty, as it is
+// set by the configuration file and the environment. Unlike Read and UseContext, the included files are
+// not loaded and no token is resolved, as both may run commands or clone repositories.
+func BaseURL(configFile string, name string) (string, error) {
+	if configFile == "" {
+		homeDir, err := homedir.Dir()
+		if err != nil {
+			return "", err
+		}
+
+		configFile = Search(homeDir)
+	}
+
+	v := viper.New()
+	if configFile != "" {
+		v.SetConfigFile(configFile)
+		if err := v.ReadInConfig(); err != nil {
+			return "", fmt.Errorf("could not read configurations: %w", err)
+		}
+	}
+
+	baseURL := v.GetString("github.baseurl")
+	if value, ok := os.LookupEnv(EnvVar("github.baseurl")); ok {
+		baseURL = value
+	}
+
+	if name == "" {
+		name = v.GetString("currentcontext")
+		if value, ok := os.LookupEnv(EnvVar("currentcontext")); ok {
+			name = value
+		}
+	}
+	if name == "" {
+		return baseURL, nil
+	}
+
+	prefix := "contexts." + strings.ToLower(name)
+	if !v.IsSet(prefix) {
+		return "", fmt.Errorf("context %q not found", name)
+	}
+	if v.IsSet(prefix + ".baseurl") {
+		baseURL = v.GetString(prefix + ".baseurl")
+	}
+
+	return baseURL, nil
+}
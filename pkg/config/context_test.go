@@ -0,0 +1,182 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBaseURL(t *testing.T) {
+	marker := filepath.Join(t.TempDir(), "token-command-ran")
+	file := writeConfig(t, "toml", `Include = ["git::https://127.0.0.1:1/hellofresh/missing.git//config.toml"]
+CurrentContext = "enterprise"
+
+[github]
+BaseURL = "https://api.github.com/"
+Token = "cmd:touch `+marker+`"
+
+[contexts.enterprise]
+BaseURL = "https://github.example.com/api/v3/"
+Token = "cmd:touch `+marker+`"
+
+[contexts.oss]
+Organization = "hellofresh-oss"
+
+[contexts.local]
+BaseURL = ""
+`)
+
+	tests := []struct {
+		name    string
+		context string
+		env     map[string]string
+		baseURL string
+		err     string
+	}{
+		{name: "current context", baseURL: "https://github.example.com/api/v3/"},
+		{name: "context without base url", context: "oss", baseURL: "https://api.github.com/"},
+		{name: "context with an empty base url", context: "local", baseURL: ""},
+		{
+			name:    "environment",
+			context: "oss",
+			env:     map[string]string{"GHCLI_GITHUB_BASEURL": "https://ghe.example.com/api/v3/"},
+			baseURL: "https://ghe.example.com/api/v3/",
+		},
+		{
+			name:    "current context from the environment",
+			env:     map[string]string{"GHCLI_CURRENTCONTEXT": "oss"},
+			baseURL: "https://api.github.com/",
+		},
+		{name: "unknown context", context: "missing", err: `context "missing" not found`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			baseURL, err := BaseURL(file, tt.context)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.baseURL, baseURL)
+		})
+	}
+
+	_, err := os.Stat(marker)
+	assert.True(t, os.IsNotExist(err), "the tokens must not be resolved")
+}
+
+func TestUseContext(t *testing.T) {
+	file := writeConfig(t, "toml", `[github]
+Organization = "hellofresh"
+Token = "ghp_github"
+Labels = [{Name = "type: bug", Color = "d73a4a"}]
+Teams = [{ID = 1, Permission = "push"}]
+Protections = {main = ["ci/build"]}
+
+[github.Settings]
+Homepage = "https://hellofresh.com"
+DefaultBranch = "main"
+
+[contexts.platform]
+Collaborators = [{Username = "alice", Permission = "admin"}]
+
+[contexts.platform.Settings]
+DefaultBranch = "develop"
+
+[contexts.oss]
+Organization = "hellofresh-oss"
+Token = "env:GHCLI_TEST_OSS_TOKEN"
+Labels = [{Name = "good first issue", Color = "7057ff"}]
+
+[contexts.brand]
+Organization = "acquired-brand"
+Teams = [{ID = 2, Permission = "pull"}]
+`)
+	t.Setenv("GHCLI_TEST_OSS_TOKEN", "ghp_oss")
+
+	tests := []struct {
+		name          string
+		context       string
+		organization  string
+		token         string
+		labels        []string
+		teams         []*Team
+		collaborators []*Collaborator
+		homepage      string
+		defaultBranch string
+	}{
+		{
+			name:          "same organization inherits the lists",
+			context:       "platform",
+			organization:  "hellofresh",
+			token:         "ghp_github",
+			labels:        []string{"type: bug"},
+			teams:         []*Team{{ID: 1, Permission: "push"}},
+			collaborators: []*Collaborator{{Username: "alice", Permission: "admin"}},
+			homepage:      "https://hellofresh.com",
+			defaultBranch: "develop",
+		},
+		{
+			name:          "other organization does not inherit the teams",
+			context:       "OSS",
+			organization:  "hellofresh-oss",
+			token:         "ghp_oss",
+			labels:        []string{"good first issue"},
+			homepage:      "https://hellofresh.com",
+			defaultBranch: "main",
+		},
+		{
+			name:          "other organization with its own teams",
+			context:       "brand",
+			organization:  "acquired-brand",
+			token:         "ghp_github",
+			labels:        []string{"type: bug"},
+			teams:         []*Team{{ID: 2, Permission: "pull"}},
+			homepage:      "https://hellofresh.com",
+			defaultBranch: "main",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := Read(context.Background(), file)
+			require.NoError(t, err)
+
+			require.NoError(t, spec.UseContext(context.Background(), tt.context))
+
+			assert.Equal(t, strings.ToLower(tt.context), spec.Context())
+			assert.Equal(t, tt.organization, spec.Github.Organization)
+			assert.Equal(t, tt.token, spec.Github.Token)
+			assert.Equal(t, tt.teams, spec.Github.Teams)
+			assert.Equal(t, tt.collaborators, spec.Github.Collaborators)
+			assert.Equal(t, BranchProtections{"main": {"ci/build"}}, spec.Github.Protections)
+			assert.Equal(t, tt.homepage, spec.Github.Settings.Homepage)
+			assert.Equal(t, tt.defaultBranch, spec.Github.Settings.DefaultBranch)
+
+			var labels []string
+			for _, label := range spec.Github.Labels {
+				labels = append(labels, label.Name)
+			}
+			assert.Equal(t, tt.labels, labels)
+		})
+	}
+}
+
+func TestUseContextNotFound(t *testing.T) {
+	spec := &Spec{Contexts: map[string]*Github{"oss": {Organization: "hellofresh-oss"}}}
+
+	err := spec.UseContext(context.Background(), "missing")
+	assert.EqualError(t, err, `context "missing" not found, available contexts: oss`)
+	assert.Empty(t, spec.Context())
+}
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// StateDir returns the directory the state of github-cli is kept in, such as the audit log:
+// $XDG_STATE_HOME/github-cli, $XDG_STATE_HOME defaults to $HOME/.local/state
+func StateDir() (string, error) {
+	stateHome := os.Getenv("XDG_STATE_HOME")
+	if stateHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		stateHome = filepath.Join(home, ".local", "state")
+	}
+
+	return filepath.Join(stateHome, "github-cli"), nil
+}
+
+// CacheDir returns the directory github-cli caches data in, such as the completion suggestions and
+// the included files fetched from git repositories:
+// $XDG_CACHE_HOME/github-cli, $XDG_CACHE_HOME defaults to $HOME/.cache
+func CacheDir() (string, error) {
+	cacheHome := os.Getenv("XDG_CACHE_HOME")
+	if cacheHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		cacheHome = filepath.Join(home, ".cache")
+	}
+
+	return filepath.Join(cacheHome, "github-cli"), nil
+}
@@ -0,0 +1,39 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	t.Setenv("XDG_STATE_HOME", "")
+	dir, err := StateDir()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, ".local", "state", "github-cli"), dir)
+
+	t.Setenv("XDG_STATE_HOME", "/var/state")
+	dir, err = StateDir()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("/var/state", "github-cli"), dir)
+}
+
+func TestCacheDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	t.Setenv("XDG_CACHE_HOME", "")
+	dir, err := CacheDir()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, ".cache", "github-cli"), dir)
+
+	t.Setenv("XDG_CACHE_HOME", "/var/cache")
+	dir, err = CacheDir()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("/var/cache", "github-cli"), dir)
+}
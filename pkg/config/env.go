@@ -0,0 +1,73 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// EnvPrefix is the prefix of the environment variables that override configuration keys
+const EnvPrefix = "GHCLI"
+
+// EnvVar returns the environment variable that overrides a configuration key,
+// e.g. GHCLI_GITHUB_ORGANIZATION for github.organization
+func EnvVar(key string) string {
+	return EnvPrefix + "_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// applyEnv overrides the configuration with the values of the GHCLI_ environment variables.
+// Lists and maps are given as JSON, e.g. GHCLI_GITHUB_TEAMS='[{"ID": 1234, "Permission": "push"}]'
+func (s *Spec) applyEnv() error {
+	var err error
+
+	walk("", reflect.ValueOf(s).Elem(), func(key string, field reflect.Value) {
+		name := EnvVar(key)
+		raw, ok := os.LookupEnv(name)
+		if !ok || err != nil {
+			return
+		}
+
+		value := reflect.New(field.Type())
+		decoder, decErr := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+			DecodeHook:       jsonHook,
+			WeaklyTypedInput: true,
+			Result:           value.Interface(),
+		})
+		if decErr == nil {
+			decErr = decoder.Decode(raw)
+		}
+		if decErr != nil {
+			err = fmt.Errorf("invalid value for %s: %w", name, decErr)
+			return
+		}
+
+		field.Set(value.Elem())
+		s.SetSource(key, SourceEnv)
+	})
+
+	return err
+}
+
+// jsonHook decodes JSON strings given for lists, maps and structs
+func jsonHook(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+	if from.Kind() != reflect.String {
+		return data, nil
+	}
+
+	switch to.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Struct, reflect.Ptr:
+	default:
+		return data, nil
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(data.(string)), &decoded); err != nil {
+		return nil, fmt.Errorf("expected a JSON value: %w", err)
+	}
+
+	return decoded, nil
+}
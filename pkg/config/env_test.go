@@ -0,0 +1,123 @@
+package config
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvVar(t *testing.T) {
+	assert.Equal(t, "GHCLI_GITHUB_ORGANIZATION", EnvVar("github.organization"))
+	assert.Equal(t, "GHCLI_GITHUB_SETTINGS_DEFAULTBRANCH", EnvVar("github.settings.defaultbranch"))
+}
+
+func TestReadEnvOverrides(t *testing.T) {
+	allowSquash := false
+
+	tests := []struct {
+		name   string
+		env    map[string]string
+		key    string
+		assert func(t *testing.T, spec *Spec)
+	}{
+		{
+			name: "scalar",
+			env:  map[string]string{"GHCLI_GITHUB_ORGANIZATION": "hellofresh-env"},
+			key:  "github.organization",
+			assert: func(t *testing.T, spec *Spec) {
+				assert.Equal(t, "hellofresh-env", spec.Github.Organization)
+			},
+		},
+		{
+			name: "nested scalar",
+			env:  map[string]string{"GHCLI_GITHUB_SETTINGS_VISIBILITY": "internal"},
+			key:  "github.settings.visibility",
+			assert: func(t *testing.T, spec *Spec) {
+				assert.Equal(t, "internal", spec.Github.Settings.Visibility)
+			},
+		},
+		{
+			name: "pointer to a boolean",
+			env:  map[string]string{"GHCLI_GITHUB_SETTINGS_ALLOWSQUASHMERGE": "false"},
+			key:  "github.settings.allowsquashmerge",
+			assert: func(t *testing.T, spec *Spec) {
+				assert.Equal(t, &allowSquash, spec.Github.Settings.AllowSquashMerge)
+			},
+		},
+		{
+			name: "JSON list",
+			env:  map[string]string{"GHCLI_GITHUB_TEAMS": `[{"ID": 1234, "Permission": "pull"}, {"ID": 5678, "Permission": "admin"}]`},
+			key:  "github.teams",
+			assert: func(t *testing.T, spec *Spec) {
+				assert.Equal(t, []*Team{{ID: 1234, Permission: "pull"}, {ID: 5678, Permission: "admin"}}, spec.Github.Teams)
+			},
+		},
+		{
+			name: "JSON list of strings",
+			env:  map[string]string{"GHCLI_GITHUB_SETTINGS_TOPICS": `["api", "payments"]`},
+			key:  "github.settings.topics",
+			assert: func(t *testing.T, spec *Spec) {
+				assert.Equal(t, []string{"api", "payments"}, spec.Github.Settings.Topics)
+			},
+		},
+		{
+			name: "JSON map",
+			env:  map[string]string{"GHCLI_GITHUB_PROTECTIONS": `{"main": ["ci/build", "ci/lint"], "release": []}`},
+			key:  "github.protections",
+			assert: func(t *testing.T, spec *Spec) {
+				assert.Equal(t, BranchProtections{"main": {"ci/build", "ci/lint"}, "release": {}}, spec.Github.Protections)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			spec, err := Read(context.Background(), writeConfig(t, "toml", configs["toml"]))
+			require.NoError(t, err)
+
+			tt.assert(t, spec)
+			assert.Equal(t, SourceEnv, spec.Source(tt.key))
+
+			var reported bool
+			for _, value := range spec.Values() {
+				if value.Key == tt.key {
+					reported = true
+					assert.Equal(t, SourceEnv, value.Source, "config show reports the environment")
+				}
+			}
+			assert.True(t, reported)
+
+			// the other values keep their source
+			assert.Equal(t, SourceFile, spec.Source("currentcontext"))
+		})
+	}
+}
+
+func TestReadInvalidEnvOverrides(t *testing.T) {
+	tests := []struct {
+		name string
+		env  string
+		raw  string
+		err  string
+	}{
+		{name: "invalid JSON list", env: "GHCLI_GITHUB_TEAMS", raw: `[{"ID": 1`, err: "invalid value for GHCLI_GITHUB_TEAMS"},
+		{name: "invalid JSON map", env: "GHCLI_GITHUB_PROTECTIONS", raw: `main=ci`, err: "invalid value for GHCLI_GITHUB_PROTECTIONS"},
+		{name: "invalid boolean", env: "GHCLI_GITHUB_REMOVEDEFAULTLABELS", raw: "maybe", err: "invalid value for GHCLI_GITHUB_REMOVEDEFAULTLABELS"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(tt.env, tt.raw)
+
+			_, err := Read(context.Background(), writeConfig(t, "toml", configs["toml"]))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
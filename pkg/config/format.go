@@ -0,0 +1,366 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/pelletier/go-toml"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// Formats are the supported configuration file formats, by file extension, in search order
+var Formats = []string{"toml", "yaml", "yml", "json"}
+
+var currentContextRegexp = regexp.MustCompile(`(?i)^\s*currentcontext\s*=`)
+
+// userKeyed are the maps whose keys are chosen by the user, e.g. branch and context names
+var userKeyed = map[string]bool{
+	"contexts":    true,
+	"protections": true,
+	"config":      true,
+}
+
+// canonicalNames maps the lower case keys to the names used in the documentation and schema
+var canonicalNames = func() map[string]string {
+	names := map[string]string{
+		"github":        "github",
+		"githubtestorg": "githubtestorg",
+		"contexts":      "contexts",
+	}
+
+	for _, t := range []reflect.Type{
+		reflect.TypeOf(Spec{}), reflect.TypeOf(Github{}), reflect.TypeOf(Team{}),
+		reflect.TypeOf(Collaborator{}), reflect.TypeOf(Label{}), reflect.TypeOf(Webhook{}),
+		reflect.TypeOf(RepositorySettings{}), reflect.TypeOf(Update{}),
+	} {
+		for i := 0; i < t.NumField(); i++ {
+			name := t.Field(i).Name
+			if _, ok := names[strings.ToLower(name)]; !ok && t.Field(i).PkgPath == "" {
+				names[strings.ToLower(name)] = name
+			}
+		}
+	}
+
+	return names
+}()
+
+// Search returns the first configuration file found in, by order:
+//
+//	./.github.<format>
+//	$XDG_CONFIG_HOME/github-cli/config.<format>, $XDG_CONFIG_HOME defaults to $HOME/.config
+//	$HOME/.github.<format>
+//
+// An empty path is returned when there is no configuration file.
+func Search(homeDir string) string {
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		configHome = filepath.Join(homeDir, ".config")
+	}
+
+	candidates := []string{
+		".github",
+		filepath.Join(configHome, "github-cli", "config"),
+		filepath.Join(homeDir, ".github"),
+	}
+
+	for _, candidate := range candidates {
+		for _, format := range Formats {
+			path := candidate + "." + format
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return path
+			}
+		}
+	}
+
+	return ""
+}
+
+// FormatOf returns the format of a configuration file by its extension
+func FormatOf(path string) (string, error) {
+	format := strings.TrimPrefix(filepath.Ext(path), ".")
+	for _, f := range Formats {
+		if strings.EqualFold(f, format) {
+			return f, nil
+		}
+	}
+
+	return "", fmt.Errorf("unsupported configuration format %q, expected one of %s", format, strings.Join(Formats, ", "))
+}
+
+// ReadSettings reads a single configuration file as it is written: includes, environment variables
+// and token references are left untouched
+func ReadSettings(path string) (map[string]interface{}, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("could not read configurations: %w", err)
+	}
+
+	settings, _ := canonicalize(v.AllSettings(), false).(map[string]interface{})
+
+	return settings, nil
+}
+
+// Marshal encodes the configuration in the given format, leaving out empty values
+func Marshal(spec *Spec, format string) ([]byte, error) {
+	settings, err := toSettings(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return MarshalSettings(settings, format)
+}
+
+// MarshalSettings encodes configuration settings, as returned by ReadSettings, in the given format
+func MarshalSettings(settings map[string]interface{}, format string) ([]byte, error) {
+	switch format {
+	case "toml":
+		tree, err := toml.TreeFromMap(settings)
+		if err != nil {
+			return nil, err
+		}
+
+		return []byte(tree.String()), nil
+	case "yaml", "yml":
+		var b bytes.Buffer
+		enc := yaml.NewEncoder(&b)
+		enc.SetIndent(2)
+		if err := enc.Encode(settings); err != nil {
+			return nil, err
+		}
+
+		return b.Bytes(), enc.Close()
+	case "json":
+		b, err := json.MarshalIndent(settings, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+
+		return append(b, '\n'), nil
+	}
+
+	return nil, fmt.Errorf("unsupported configuration format %q", format)
+}
+
+// toSettings converts the configuration to settings, leaving out empty values
+func toSettings(spec *Spec) (map[string]interface{}, error) {
+	b, err := json.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal configuration: %w", err)
+	}
+
+	var settings interface{}
+	if err := json.Unmarshal(b, &settings); err != nil {
+		return nil, fmt.Errorf("could not marshal configuration: %w", err)
+	}
+
+	pruned, _ := canonicalize(prune(settings), false).(map[string]interface{})
+	if pruned == nil {
+		pruned = make(map[string]interface{})
+	}
+
+	return pruned, nil
+}
+
+// prune removes empty values
+func prune(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, inner := range v {
+			if pruned := prune(inner); pruned == nil {
+				delete(v, key)
+			} else {
+				v[key] = pruned
+			}
+		}
+
+		if len(v) == 0 {
+			return nil
+		}
+	case []interface{}:
+		var items []interface{}
+		for _, inner := range v {
+			if pruned := prune(inner); pruned != nil {
+				items = append(items, pruned)
+			}
+		}
+
+		if len(items) == 0 {
+			return nil
+		}
+
+		return items
+	case float64:
+		if v == 0 {
+			return nil
+		}
+	case string:
+		if v == "" {
+			return nil
+		}
+	case bool:
+		if !v {
+			return nil
+		}
+	case nil:
+		return nil
+	}
+
+	return value
+}
+
+// canonicalize renames the keys as documented and converts values so every format can encode them
+func canonicalize(value interface{}, userKeys bool) interface{} {
+	if m, ok := toStringMap(value); ok {
+		result := make(map[string]interface{}, len(m))
+		for key, inner := range m {
+			name := key
+			if canonical, ok := canonicalNames[strings.ToLower(key)]; ok && !userKeys {
+				name = canonical
+			}
+
+			result[name] = canonicalize(inner, !userKeys && userKeyed[strings.ToLower(key)])
+		}
+
+		return result
+	}
+
+	switch v := value.(type) {
+	case []interface{}:
+		items := make([]interface{}, len(v))
+		for i, inner := range v {
+			items[i] = canonicalize(inner, false)
+		}
+
+		return items
+	case float64:
+		// JSON numbers are decoded as floats, IDs must stay integers
+		if v == math.Trunc(v) {
+			return int64(v)
+		}
+	case int:
+		return int64(v)
+	}
+
+	return value
+}
+
+// SetCurrentContext changes the default context in a configuration file. TOML and YAML files are
+// edited in place to keep their comments and token references.
+func SetCurrentContext(file string, name string) error {
+	format, err := FormatOf(file)
+	if err != nil {
+		return err
+	}
+
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("could not read configuration file: %w", err)
+	}
+
+	name = strings.ToLower(name)
+
+	switch format {
+	case "toml":
+		b = setTOMLCurrentContext(b, name)
+	case "yaml", "yml":
+		if b, err = setYAMLCurrentContext(b, name); err != nil {
+			return err
+		}
+	default:
+		var settings map[string]interface{}
+		if err := json.Unmarshal(b, &settings); err != nil {
+			return fmt.Errorf("could not parse configuration file: %w", err)
+		}
+
+		for key := range settings {
+			if strings.EqualFold(key, "currentcontext") {
+				delete(settings, key)
+			}
+		}
+		settings["CurrentContext"] = name
+
+		if b, err = json.MarshalIndent(settings, "", "  "); err != nil {
+			return err
+		}
+		b = append(b, '\n')
+	}
+
+	if err := ioutil.WriteFile(file, b, 0600); err != nil {
+		return fmt.Errorf("could not write configuration file: %w", err)
+	}
+
+	return nil
+}
+
+func setTOMLCurrentContext(b []byte, name string) []byte {
+	setting := fmt.Sprintf("CurrentContext = %q", name)
+	lines := strings.Split(string(b), "\n")
+
+	// top level keys must come before the first table
+	insertAt := len(lines)
+	for i, line := range lines {
+		if currentContextRegexp.MatchString(line) {
+			lines[i] = setting
+			return []byte(strings.Join(lines, "\n"))
+		}
+
+		if strings.HasPrefix(strings.TrimSpace(line), "[") {
+			insertAt = i
+			break
+		}
+	}
+
+	lines = append(lines[:insertAt], append([]string{setting, ""}, lines[insertAt:]...)...)
+
+	return []byte(strings.Join(lines, "\n"))
+}
+
+func setYAMLCurrentContext(b []byte, name string) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, fmt.Errorf("could not parse configuration file: %w", err)
+	}
+
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("configuration file is not a mapping")
+	}
+
+	found := false
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if strings.EqualFold(root.Content[i].Value, "currentcontext") {
+			root.Content[i+1].SetString(name)
+			found = true
+		}
+	}
+
+	if !found {
+		key, value := &yaml.Node{}, &yaml.Node{}
+		key.SetString("CurrentContext")
+		value.SetString(name)
+		root.Content = append([]*yaml.Node{key, value}, root.Content...)
+	}
+
+	var out bytes.Buffer
+	enc := yaml.NewEncoder(&out)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, err
+	}
+
+	return out.Bytes(), enc.Close()
+}
@@ -0,0 +1,227 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// configs hold the same configuration in each of the formats
+var configs = map[string]string{
+	"toml": `CurrentContext = "oss"
+
+[github]
+Organization = "hellofresh"
+Token = "env:GITHUB_TOKEN"
+
+[github.Settings]
+Visibility = "private"
+Topics = ["go", "cli"]
+AllowSquashMerge = true
+
+[[github.Teams]]
+ID = 1
+Permission = "push"
+
+[[github.Labels]]
+Name = "bug"
+Color = "d73a4a"
+Aliases = ["defect"]
+
+[github.Protections]
+main = ["ci/build"]
+
+[contexts.oss]
+Organization = "hellofresh-oss"
+`,
+	"yaml": `CurrentContext: oss
+github:
+  Organization: hellofresh
+  Token: env:GITHUB_TOKEN
+  Settings:
+    Visibility: private
+    Topics: [go, cli]
+    AllowSquashMerge: true
+  Teams:
+    - ID: 1
+      Permission: push
+  Labels:
+    - Name: bug
+      Color: d73a4a
+      Aliases: [defect]
+  Protections:
+    main: [ci/build]
+contexts:
+  oss:
+    Organization: hellofresh-oss
+`,
+	"json": `{
+  "CurrentContext": "oss",
+  "github": {
+    "Organization": "hellofresh",
+    "Token": "env:GITHUB_TOKEN",
+    "Settings": {"Visibility": "private", "Topics": ["go", "cli"], "AllowSquashMerge": true},
+    "Teams": [{"ID": 1, "Permission": "push"}],
+    "Labels": [{"Name": "bug", "Color": "d73a4a", "Aliases": ["defect"]}],
+    "Protections": {"main": ["ci/build"]}
+  },
+  "contexts": {"oss": {"Organization": "hellofresh-oss"}}
+}
+`,
+}
+
+func init() {
+	configs["yml"] = configs["yaml"]
+}
+
+// writeConfig writes content to a configuration file of the given format in a temporary directory
+func writeConfig(t *testing.T, format string, content string) string {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), "config."+format)
+	require.NoError(t, ioutil.WriteFile(file, []byte(content), 0600))
+
+	return file
+}
+
+// assertConfig checks the values of configs were read
+func assertConfig(t *testing.T, spec *Spec) {
+	t.Helper()
+
+	assert.Equal(t, "oss", spec.CurrentContext)
+	assert.Equal(t, "hellofresh", spec.Github.Organization)
+	assert.Equal(t, "env:GITHUB_TOKEN", spec.Github.Token)
+	assert.Equal(t, "private", spec.Github.Settings.Visibility)
+	assert.Equal(t, []string{"go", "cli"}, spec.Github.Settings.Topics)
+	require.NotNil(t, spec.Github.Settings.AllowSquashMerge)
+	assert.True(t, *spec.Github.Settings.AllowSquashMerge)
+	assert.Nil(t, spec.Github.Settings.AllowMergeCommit)
+	assert.Equal(t, []*Team{{ID: 1, Permission: "push"}}, spec.Github.Teams)
+	assert.Equal(t, []*Label{{Name: "bug", Color: "d73a4a", Aliases: []string{"defect"}}}, spec.Github.Labels)
+	assert.Equal(t, BranchProtections{"main": {"ci/build"}}, spec.Github.Protections)
+	require.Contains(t, spec.Contexts, "oss")
+	assert.Equal(t, "hellofresh-oss", spec.Contexts["oss"].Organization)
+}
+
+func TestRead(t *testing.T) {
+	for format, content := range configs {
+		t.Run(format, func(t *testing.T) {
+			file := writeConfig(t, format, content)
+
+			spec, err := Read(context.Background(), file)
+			require.NoError(t, err)
+
+			assertConfig(t, spec)
+			assert.Equal(t, SourceFile, spec.Source("github.settings.visibility"))
+			assert.Equal(t, SourceDefault, spec.Source("github.settings.homepage"))
+			assert.Empty(t, spec.unknown)
+		})
+	}
+}
+
+func TestReadUnsupportedFormat(t *testing.T) {
+	file := writeConfig(t, "ini", "organization = hellofresh")
+
+	_, err := Read(context.Background(), file)
+	assert.EqualError(t, err, `unsupported configuration format "ini", expected one of toml, yaml, yml, json`)
+}
+
+func TestConvert(t *testing.T) {
+	for from, content := range configs {
+		for _, to := range Formats {
+			t.Run(from+" to "+to, func(t *testing.T) {
+				settings, err := ReadSettings(writeConfig(t, from, content))
+				require.NoError(t, err)
+
+				b, err := MarshalSettings(settings, to)
+				require.NoError(t, err)
+
+				spec, err := Read(context.Background(), writeConfig(t, to, string(b)))
+				require.NoError(t, err)
+
+				assertConfig(t, spec)
+				assert.Empty(t, spec.unknown, "converted keys must keep their names")
+			})
+		}
+	}
+}
+
+func TestMarshalUnsupportedFormat(t *testing.T) {
+	_, err := MarshalSettings(map[string]interface{}{}, "ini")
+	assert.EqualError(t, err, `unsupported configuration format "ini"`)
+}
+
+func TestValidate(t *testing.T) {
+	invalid := map[string]string{
+		"toml": `[github]
+Organization = "hellofresh"
+Unknown = true
+
+[github.Settings]
+Visibility = "secret"
+
+[[github.Teams]]
+ID = 1
+Permission = "write"
+
+[Update]
+Channel = "nightly"
+`,
+		"yaml": `github:
+  Organization: hellofresh
+  Unknown: true
+  Settings:
+    Visibility: secret
+  Teams:
+    - ID: 1
+      Permission: write
+Update:
+  Channel: nightly
+`,
+		"json": `{
+  "github": {
+    "Organization": "hellofresh",
+    "Unknown": true,
+    "Settings": {"Visibility": "secret"},
+    "Teams": [{"ID": 1, "Permission": "write"}]
+  },
+  "Update": {"Channel": "nightly"}
+}
+`,
+	}
+	invalid["yml"] = invalid["yaml"]
+
+	for _, format := range Formats {
+		t.Run(format, func(t *testing.T) {
+			valid, err := Read(context.Background(), writeConfig(t, format, configs[format]))
+			require.NoError(t, err)
+			assert.NoError(t, valid.Validate())
+
+			file := writeConfig(t, format, invalid[format])
+			spec, err := Read(context.Background(), file)
+			require.NoError(t, err)
+
+			var merr *multierror.Error
+			require.True(t, errors.As(spec.Validate(), &merr))
+
+			keys := make(map[string]string)
+			for _, err := range merr.Errors {
+				var fieldErr *FieldError
+				require.True(t, errors.As(err, &fieldErr))
+				assert.Equal(t, file, fieldErr.File)
+				keys[fieldErr.Key] = fieldErr.Message
+			}
+
+			assert.Contains(t, keys, "github.unknown")
+			assert.Contains(t, keys, "github.settings.visibility")
+			assert.Contains(t, keys, "github.teams[0].permission")
+			assert.Contains(t, keys, "update.channel")
+		})
+	}
+}
@@ -0,0 +1,429 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/storage/memory"
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/spf13/viper"
+
+	"github.com/hellofresh/github-cli/pkg/log"
+)
+
+type (
+	// includeLoader reads included configuration files, following their own includes
+	includeLoader struct {
+		ctx context.Context
+		// own are the settings of the including file, used to find a token for private repositories
+		own map[string]interface{}
+		// loading detects include cycles
+		loading map[string]bool
+		// files are the loaded settings by include, in merge order
+		files []includedFile
+	}
+
+	includedFile struct {
+		name     string
+		settings map[string]interface{}
+	}
+)
+
+const (
+	gitIncludePrefix = "git::"
+	// includeCacheTTL is how long a file fetched from a git repository is used before fetching it again
+	includeCacheTTL = time.Hour
+)
+
+// listIdentities are the fields identifying the items of the lists that are merged across files,
+// instead of being replaced
+var listIdentities = map[string]string{
+	"teams":         "id",
+	"collaborators": "username",
+	"labels":        "name",
+	"webhooks":      "config.url",
+}
+
+func newIncludeLoader(ctx context.Context, own map[string]interface{}) *includeLoader {
+	return &includeLoader{
+		ctx:     ctx,
+		own:     own,
+		loading: make(map[string]bool),
+	}
+}
+
+// loadAll reads the includes in order and merges them, later files take precedence
+func (l *includeLoader) loadAll(includes []string, dir string) (map[string]interface{}, error) {
+	settings := make(map[string]interface{})
+
+	for _, include := range includes {
+		included, err := l.load(include, dir)
+		if err != nil {
+			return nil, err
+		}
+
+		mergeSettings(settings, included)
+	}
+
+	return settings, nil
+}
+
+// load reads an include and the files it includes. Local paths are relative to dir.
+func (l *includeLoader) load(include string, dir string) (map[string]interface{}, error) {
+	var (
+		name    string
+		content []byte
+		err     error
+	)
+
+	if strings.HasPrefix(include, gitIncludePrefix) {
+		name = include
+		content, err = l.fetch(include)
+	} else {
+		if name, err = homedir.Expand(include); err != nil {
+			return nil, err
+		}
+		if !filepath.IsAbs(name) {
+			if strings.HasPrefix(dir, gitIncludePrefix) {
+				return nil, fmt.Errorf("relative include %q is not supported in %s, use a git:: reference", include, dir)
+			}
+			name = filepath.Join(dir, name)
+		}
+		content, err = ioutil.ReadFile(name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read included configuration %s: %w", include, err)
+	}
+
+	if l.loading[name] {
+		return nil, fmt.Errorf("configuration %s includes itself", name)
+	}
+	l.loading[name] = true
+	defer delete(l.loading, name)
+
+	log.WithContext(l.ctx).Debugf("Including config from %s...", name)
+
+	v := viper.New()
+	v.SetConfigType(strings.TrimPrefix(filepath.Ext(strings.SplitN(name, "?", 2)[0]), "."))
+	if err := v.ReadConfig(bytes.NewReader(content)); err != nil {
+		return nil, fmt.Errorf("could not parse included configuration %s: %w", name, err)
+	}
+
+	includeDir := filepath.Dir(name)
+	if strings.HasPrefix(name, gitIncludePrefix) {
+		includeDir = name
+	}
+
+	settings, err := l.loadAll(v.GetStringSlice("include"), includeDir)
+	if err != nil {
+		return nil, err
+	}
+
+	own := v.AllSettings()
+	delete(own, "include")
+	mergeSettings(settings, own)
+
+	l.files = append(l.files, includedFile{name: name, settings: own})
+
+	return settings, nil
+}
+
+// origin returns the last included file, the one that wins the merge, that sets a key
+func (l *includeLoader) origin(key string) string {
+	path := strings.Split(key, ".")
+
+	for i := len(l.files) - 1; i >= 0; i-- {
+		if lookup(l.files[i].settings, path) {
+			return l.files[i].name
+		}
+	}
+
+	return ""
+}
+
+// fetch reads a file from a git repository, referenced as git::<repository url>//<path>[?ref=<branch>].
+// Fetched files are cached for includeCacheTTL, and the cache is used when the repository can't be reached.
+func (l *includeLoader) fetch(include string) ([]byte, error) {
+	logger := log.WithContext(l.ctx)
+
+	repoURL, path, ref, err := parseGitInclude(include)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheFile := ""
+	if cacheDir, err := CacheDir(); err == nil {
+		sum := sha256.Sum256([]byte(include))
+		cacheFile = filepath.Join(cacheDir, "includes", hex.EncodeToString(sum[:]))
+	}
+
+	if info, err := os.Stat(cacheFile); cacheFile != "" && err == nil && time.Since(info.ModTime()) < includeCacheTTL {
+		logger.Debugf("Using cached %s", include)
+		return ioutil.ReadFile(cacheFile)
+	}
+
+	content, err := l.clone(repoURL, path, ref)
+	if err != nil {
+		if cached, cacheErr := ioutil.ReadFile(cacheFile); cacheFile != "" && cacheErr == nil {
+			logger.WithError(err).Warnf("Could not fetch %s, using the cached copy", include)
+			return cached, nil
+		}
+
+		return nil, err
+	}
+
+	if cacheFile != "" {
+		if err := os.MkdirAll(filepath.Dir(cacheFile), 0700); err == nil {
+			if err := ioutil.WriteFile(cacheFile, content, 0600); err != nil {
+				logger.WithError(err).Debug("Could not cache included configuration")
+			}
+		}
+	}
+
+	return content, nil
+}
+
+func (l *includeLoader) clone(repoURL string, path string, ref string) ([]byte, error) {
+	opts := &git.CloneOptions{
+		URL:          repoURL,
+		Depth:        1,
+		SingleBranch: true,
+	}
+	if ref != "" {
+		opts.ReferenceName = plumbing.NewBranchReferenceName(ref)
+	}
+	opts.Auth = l.auth(repoURL)
+
+	r, err := git.CloneContext(l.ctx, memory.NewStorage(), memfs.New(), opts)
+	if err != nil {
+		return nil, fmt.Errorf("could not clone %s: %w", repoURL, err)
+	}
+
+	wt, err := r.Worktree()
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := wt.Filesystem.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open %s in %s: %w", path, repoURL, err)
+	}
+	defer f.Close()
+
+	return ioutil.ReadAll(f)
+}
+
+// auth returns the credentials to clone a repository with. The github token is only sent over https to
+// github.com and to the GitHub Enterprise server of the configuration, other repositories are cloned anonymously.
+func (l *includeLoader) auth(repoURL string) transport.AuthMethod {
+	u, err := url.Parse(repoURL)
+	if err != nil || u.Scheme != "https" {
+		return nil
+	}
+
+	trusted := false
+	for _, host := range l.tokenHosts() {
+		if strings.EqualFold(u.Hostname(), host) {
+			trusted = true
+			break
+		}
+	}
+	if !trusted {
+		return nil
+	}
+
+	token := l.token()
+	if token == "" {
+		return nil
+	}
+
+	return &http.BasicAuth{Username: "github-cli", Password: token}
+}
+
+// tokenHosts returns the hosts the github token of the including configuration belongs to
+func (l *includeLoader) tokenHosts() []string {
+	hosts := []string{"github.com"}
+
+	baseURL := os.Getenv(EnvVar("github.baseurl"))
+	if baseURL == "" {
+		if gh, ok := l.own["github"].(map[string]interface{}); ok {
+			baseURL, _ = gh["baseurl"].(string)
+		}
+	}
+	if u, err := url.Parse(baseURL); baseURL != "" && err == nil && u.Hostname() != "" {
+		hosts = append(hosts, u.Hostname())
+	}
+
+	return hosts
+}
+
+// token finds the github token of the including configuration to access private repositories
+func (l *includeLoader) token() string {
+	token := os.Getenv(EnvVar("github.token"))
+	if token == "" {
+		if gh, ok := l.own["github"].(map[string]interface{}); ok {
+			token, _ = gh["token"].(string)
+		}
+	}
+	if token == "" {
+		token = os.Getenv("GITHUB_TOKEN")
+	}
+
+	secret, err := ResolveSecret(l.ctx, token)
+	if err != nil {
+		log.WithContext(l.ctx).WithError(err).Debug("Could not resolve token for included configuration")
+		return ""
+	}
+
+	return secret
+}
+
+// parseGitInclude splits git::https://github.com/org/repo.git//path/file.toml?ref=main
+func parseGitInclude(include string) (repoURL string, path string, ref string, err error) {
+	rest := strings.TrimPrefix(include, gitIncludePrefix)
+
+	if i := strings.LastIndex(rest, "?ref="); i >= 0 {
+		rest, ref = rest[:i], rest[i+len("?ref="):]
+	}
+
+	schemeEnd := 0
+	if i := strings.Index(rest, "://"); i >= 0 {
+		schemeEnd = i + len("://")
+	}
+
+	i := strings.Index(rest[schemeEnd:], "//")
+	if i < 0 {
+		return "", "", "", fmt.Errorf("invalid include %q, expected git::<repository url>//<path>[?ref=<branch>]", include)
+	}
+
+	return rest[:schemeEnd+i], rest[schemeEnd+i+2:], ref, nil
+}
+
+// mergeSettings merges src into dst following these rules:
+//   - maps, e.g. sections, contexts and protections, are merged key by key
+//   - lists of teams, collaborators, labels and webhooks are merged by their ID, username, name and url:
+//     an item of src replaces the item of dst with the same identity, other items are appended
+//   - any other value of src replaces the one in dst
+func mergeSettings(dst map[string]interface{}, src map[string]interface{}) {
+	for key, srcValue := range src {
+		dstValue, exists := dst[key]
+		if !exists {
+			dst[key] = srcValue
+			continue
+		}
+
+		dstMap, dstIsMap := toStringMap(dstValue)
+		srcMap, srcIsMap := toStringMap(srcValue)
+		if dstIsMap && srcIsMap {
+			mergeSettings(dstMap, srcMap)
+			dst[key] = dstMap
+			continue
+		}
+
+		dstList, dstIsList := dstValue.([]interface{})
+		srcList, srcIsList := srcValue.([]interface{})
+		if identity, ok := listIdentities[strings.ToLower(key)]; ok && dstIsList && srcIsList {
+			dst[key] = mergeList(dstList, srcList, identity)
+			continue
+		}
+
+		dst[key] = srcValue
+	}
+}
+
+func mergeList(dst []interface{}, src []interface{}, identity string) []interface{} {
+	merged := append([]interface{}{}, dst...)
+
+	for _, item := range src {
+		id, ok := itemIdentity(item, identity)
+		if !ok {
+			merged = append(merged, item)
+			continue
+		}
+
+		replaced := false
+		for i, existing := range merged {
+			if existingID, ok := itemIdentity(existing, identity); ok && existingID == id {
+				merged[i] = item
+				replaced = true
+				break
+			}
+		}
+
+		if !replaced {
+			merged = append(merged, item)
+		}
+	}
+
+	return merged
+}
+
+// itemIdentity returns the value of a dotted field of a list item, compared case insensitively
+func itemIdentity(item interface{}, identity string) (string, bool) {
+	value := item
+	for _, field := range strings.Split(identity, ".") {
+		m, ok := toStringMap(value)
+		if !ok {
+			return "", false
+		}
+
+		found := false
+		for k, v := range m {
+			if strings.EqualFold(k, field) {
+				value, found = v, true
+				break
+			}
+		}
+		if !found {
+			return "", false
+		}
+	}
+
+	return strings.ToLower(fmt.Sprint(value)), true
+}
+
+// lookup checks if a dotted path is set in the settings
+func lookup(settings map[string]interface{}, path []string) bool {
+	value := interface{}(settings)
+	for _, key := range path {
+		m, ok := toStringMap(value)
+		if !ok {
+			return false
+		}
+
+		if value, ok = m[key]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func toStringMap(value interface{}) (map[string]interface{}, bool) {
+	switch m := value.(type) {
+	case map[string]interface{}:
+		return m, true
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{}, len(m))
+		for k, v := range m {
+			converted[fmt.Sprint(k)] = v
+		}
+		return converted, true
+	}
+
+	return nil, false
+}
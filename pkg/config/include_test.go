@@ -0,0 +1,302 @@
+package config
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeSettings(t *testing.T) {
+	dst := map[string]interface{}{
+		"github": map[string]interface{}{
+			"organization": "hellofresh",
+			"token":        "env:GITHUB_TOKEN",
+			"teams":        []interface{}{map[string]interface{}{"id": 1, "permission": "pull"}},
+			"labels": []interface{}{
+				map[string]interface{}{"name": "bug", "color": "d73a4a"},
+				map[string]interface{}{"name": "wontfix", "color": "ffffff"},
+			},
+			"protections": map[string]interface{}{"main": []interface{}{"ci/build"}},
+			"settings":    map[string]interface{}{"topics": []interface{}{"go"}, "homepage": "https://hellofresh.com"},
+		},
+	}
+	src := map[string]interface{}{
+		"github": map[string]interface{}{
+			"organization": "hellofresh-oss",
+			"teams":        []interface{}{map[string]interface{}{"id": 1, "permission": "push"}, map[string]interface{}{"id": 2, "permission": "pull"}},
+			"labels": []interface{}{
+				map[interface{}]interface{}{"Name": "BUG", "Color": "ee0701"},
+				map[string]interface{}{"name": "needs-review", "color": "ededed"},
+			},
+			"webhooks":    []interface{}{map[string]interface{}{"config": map[string]interface{}{"url": "https://ci.example.com"}}},
+			"protections": map[string]interface{}{"develop": []interface{}{"ci/test"}},
+			"settings":    map[string]interface{}{"topics": []interface{}{"oss"}},
+		},
+		"currentcontext": "oss",
+	}
+
+	mergeSettings(dst, src)
+
+	assert.Equal(t, map[string]interface{}{
+		"github": map[string]interface{}{
+			"organization": "hellofresh-oss",
+			"token":        "env:GITHUB_TOKEN",
+			"teams":        []interface{}{map[string]interface{}{"id": 1, "permission": "push"}, map[string]interface{}{"id": 2, "permission": "pull"}},
+			"labels": []interface{}{
+				map[interface{}]interface{}{"Name": "BUG", "Color": "ee0701"},
+				map[string]interface{}{"name": "wontfix", "color": "ffffff"},
+				map[string]interface{}{"name": "needs-review", "color": "ededed"},
+			},
+			"webhooks":    []interface{}{map[string]interface{}{"config": map[string]interface{}{"url": "https://ci.example.com"}}},
+			"protections": map[string]interface{}{"main": []interface{}{"ci/build"}, "develop": []interface{}{"ci/test"}},
+			"settings":    map[string]interface{}{"topics": []interface{}{"oss"}, "homepage": "https://hellofresh.com"},
+		},
+		"currentcontext": "oss",
+	}, dst)
+}
+
+func TestMergeList(t *testing.T) {
+	dst := []interface{}{
+		map[string]interface{}{"username": "alice", "permission": "pull"},
+		"not a collaborator",
+	}
+	src := []interface{}{
+		map[string]interface{}{"Username": "Alice", "permission": "admin"},
+		map[string]interface{}{"permission": "push"},
+		map[string]interface{}{"username": "bob", "permission": "pull"},
+	}
+
+	merged := mergeList(dst, src, "username")
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"Username": "Alice", "permission": "admin"},
+		"not a collaborator",
+		map[string]interface{}{"permission": "push"},
+		map[string]interface{}{"username": "bob", "permission": "pull"},
+	}, merged)
+	assert.Len(t, dst, 2, "the merged list is a copy")
+}
+
+func TestReadIncludes(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "shared", "teams.yaml"), `github:
+  Teams:
+    - ID: 1
+      Permission: pull
+  Labels:
+    - Name: bug
+      Color: d73a4a
+`)
+	writeFile(t, filepath.Join(dir, "shared", "base.toml"), `Include = ["teams.yaml"]
+
+[github]
+Organization = "hellofresh"
+Labels = [{Name = "needs-review", Color = "ededed"}]
+
+[github.Settings]
+Homepage = "https://hellofresh.com"
+`)
+	file := filepath.Join(dir, "config.toml")
+	writeFile(t, file, `Include = ["shared/base.toml"]
+
+[github]
+Teams = [{ID = 1, Permission = "push"}]
+`)
+
+	spec, err := Read(context.Background(), file)
+	require.NoError(t, err)
+
+	assert.Equal(t, "hellofresh", spec.Github.Organization)
+	assert.Equal(t, "https://hellofresh.com", spec.Github.Settings.Homepage)
+	assert.Equal(t, []*Team{{ID: 1, Permission: "push"}}, spec.Github.Teams)
+	assert.Equal(t, []*Label{{Name: "bug", Color: "d73a4a"}, {Name: "needs-review", Color: "ededed"}}, spec.Github.Labels)
+
+	assert.Equal(t, SourceFile, spec.Source("github.teams"))
+	assert.Equal(t, SourceInclude, spec.Source("github.organization"))
+	assert.Equal(t, filepath.Join(dir, "shared", "base.toml"), spec.Origin("github.organization"))
+	assert.Equal(t, filepath.Join(dir, "shared", "base.toml"), spec.Origin("github.labels"))
+}
+
+func TestReadIncludeCycle(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "a.toml"), `Include = ["b.toml"]`)
+	writeFile(t, filepath.Join(dir, "b.toml"), `Include = ["a.toml"]`)
+	file := filepath.Join(dir, "config.toml")
+	writeFile(t, file, `Include = ["a.toml"]`)
+
+	_, err := Read(context.Background(), file)
+	assert.EqualError(t, err, "configuration "+filepath.Join(dir, "a.toml")+" includes itself")
+}
+
+func TestReadIncludeMissing(t *testing.T) {
+	file := writeConfig(t, "toml", `Include = ["missing.toml"]`)
+
+	_, err := Read(context.Background(), file)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "could not read included configuration missing.toml")
+}
+
+func TestReadGitInclude(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	repoDir := t.TempDir()
+	writeFile(t, filepath.Join(repoDir, "github", "config.toml"), `Include = ["labels.toml"]`)
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "main"},
+		{"add", "."},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "config"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	include := "git::file://" + filepath.ToSlash(repoDir) + "//github/config.toml?ref=main"
+	file := writeConfig(t, "toml", `Include = ["`+include+`"]`)
+
+	_, err := Read(context.Background(), file)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `relative include "labels.toml" is not supported in `+include)
+
+	writeFile(t, filepath.Join(repoDir, "github", "config.toml"), "[github]\nOrganization = \"hellofresh\"\n")
+	cmd := exec.Command("git", "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-am", "organization")
+	cmd.Dir = repoDir
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	// a new cache, the previous include was cached even though it could not be loaded
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	spec, err := Read(context.Background(), file)
+	require.NoError(t, err)
+	assert.Equal(t, "hellofresh", spec.Github.Organization)
+	assert.Equal(t, include, spec.Origin("github.organization"))
+
+	require.NoError(t, os.RemoveAll(repoDir))
+
+	spec, err = Read(context.Background(), file)
+	require.NoError(t, err, "the cached copy is used")
+	assert.Equal(t, "hellofresh", spec.Github.Organization)
+}
+
+func TestIncludeAuth(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the token command is a shell script")
+	}
+
+	dir := t.TempDir()
+	marker := filepath.Join(dir, "token-command-ran")
+	// commands are run without a shell
+	script := filepath.Join(dir, "token.sh")
+	writeFile(t, script, "#!/bin/sh\ntouch "+marker+"\necho ghp_cmd\n")
+	require.NoError(t, os.Chmod(script, 0700))
+
+	tests := []struct {
+		name     string
+		repoURL  string
+		own      map[string]interface{}
+		env      map[string]string
+		token    string
+		resolved bool
+	}{
+		{
+			name:    "github.com",
+			repoURL: "https://github.com/hellofresh/config.git",
+			env:     map[string]string{"GITHUB_TOKEN": "ghp_env"},
+			token:   "ghp_env",
+		},
+		{
+			name:     "token of the configuration",
+			repoURL:  "https://GitHub.com/hellofresh/config.git",
+			own:      map[string]interface{}{"github": map[string]interface{}{"token": "cmd:" + script}},
+			token:    "ghp_cmd",
+			resolved: true,
+		},
+		{
+			name:    "token of the environment takes precedence",
+			repoURL: "https://github.com/hellofresh/config.git",
+			own:     map[string]interface{}{"github": map[string]interface{}{"token": "ghp_file"}},
+			env:     map[string]string{"GHCLI_GITHUB_TOKEN": "ghp_ghcli", "GITHUB_TOKEN": "ghp_env"},
+			token:   "ghp_ghcli",
+		},
+		{
+			name:    "enterprise server of the configuration",
+			repoURL: "https://github.example.com/hellofresh/config.git",
+			own:     map[string]interface{}{"github": map[string]interface{}{"token": "ghp_file", "baseurl": "https://github.example.com/api/v3/"}},
+			token:   "ghp_file",
+		},
+		{
+			name:    "enterprise server of the environment",
+			repoURL: "https://github.example.com/hellofresh/config.git",
+			env:     map[string]string{"GHCLI_GITHUB_BASEURL": "https://github.example.com/api/v3/", "GITHUB_TOKEN": "ghp_env"},
+			token:   "ghp_env",
+		},
+		{
+			name:    "other host",
+			repoURL: "https://gitlab.example.com/hellofresh/config.git",
+			own:     map[string]interface{}{"github": map[string]interface{}{"token": "cmd:" + script, "baseurl": "https://github.example.com/api/v3/"}},
+		},
+		{
+			name:    "host with github.com as prefix",
+			repoURL: "https://github.com.example.com/hellofresh/config.git",
+			env:     map[string]string{"GITHUB_TOKEN": "ghp_env"},
+		},
+		{
+			name:    "plain http",
+			repoURL: "http://github.com/hellofresh/config.git",
+			env:     map[string]string{"GITHUB_TOKEN": "ghp_env"},
+		},
+		{
+			name:    "no token",
+			repoURL: "https://github.com/hellofresh/config.git",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"GITHUB_TOKEN", "GHCLI_GITHUB_TOKEN", "GHCLI_GITHUB_BASEURL"} {
+				t.Setenv(key, tt.env[key])
+			}
+			_ = os.Remove(marker)
+
+			auth := newIncludeLoader(context.Background(), tt.own).auth(tt.repoURL)
+			if tt.token == "" {
+				assert.Nil(t, auth)
+			} else {
+				assert.Equal(t, &http.BasicAuth{Username: "github-cli", Password: tt.token}, auth)
+			}
+
+			_, err := os.Stat(marker)
+			assert.Equal(t, tt.resolved, err == nil, "the token is only resolved for the trusted hosts")
+		})
+	}
+}
+
+func TestParseGitInclude(t *testing.T) {
+	repoURL, path, ref, err := parseGitInclude("git::https://github.com/hellofresh/config.git//github/base.toml?ref=main")
+	require.NoError(t, err)
+	assert.Equal(t, "https://github.com/hellofresh/config.git", repoURL)
+	assert.Equal(t, "github/base.toml", path)
+	assert.Equal(t, "main", ref)
+
+	_, _, _, err = parseGitInclude("git::https://github.com/hellofresh/config.git")
+	assert.Error(t, err)
+}
+
+func writeFile(t *testing.T, file string, content string) {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(file), 0700))
+	require.NoError(t, os.WriteFile(file, []byte(content), 0600))
+}
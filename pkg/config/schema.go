@@ -0,0 +1,12 @@
+package config
+
+import (
+	// embed is required for the schema
+	_ "embed"
+)
+
+// Schema is the JSON Schema describing the configuration file. Editors can use it to validate and
+// autocomplete the configuration.
+//
+//go:embed schema.json
+var Schema []byte
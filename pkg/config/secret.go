@@ -0,0 +1,150 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"reflect"
+	"runtime"
+	"strings"
+
+	homedir "github.com/mitchellh/go-homedir"
+)
+
+const (
+	secretEnvPrefix  = "env:"
+	secretFilePrefix = "file:"
+	secretCmdPrefix  = "cmd:"
+)
+
+// IsSecretReference checks if a value points to a secret instead of holding it
+func IsSecretReference(value string) bool {
+	return strings.HasPrefix(value, secretEnvPrefix) ||
+		strings.HasPrefix(value, secretFilePrefix) ||
+		strings.HasPrefix(value, secretCmdPrefix)
+}
+
+// ResolveSecret returns the secret a value points to. Supported references are
+//
+//	env:NAME        the value of the environment variable NAME
+//	file:/path      the content of the file, without surrounding whitespace
+//	cmd:pass show   the output of the command, which is run without a shell
+//
+// Values that are not references are returned as they are.
+func ResolveSecret(ctx context.Context, value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, secretEnvPrefix):
+		name := strings.TrimPrefix(value, secretEnvPrefix)
+		secret := os.Getenv(name)
+		if secret == "" {
+			return "", fmt.Errorf("environment variable %s is not set", name)
+		}
+
+		return secret, nil
+	case strings.HasPrefix(value, secretFilePrefix):
+		path, err := homedir.Expand(strings.TrimPrefix(value, secretFilePrefix))
+		if err != nil {
+			return "", err
+		}
+
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("could not read secret file: %w", err)
+		}
+
+		return strings.TrimSpace(string(b)), nil
+	case strings.HasPrefix(value, secretCmdPrefix):
+		args := strings.Fields(strings.TrimPrefix(value, secretCmdPrefix))
+		if len(args) == 0 {
+			return "", errors.New("no command given")
+		}
+
+		var stdout bytes.Buffer
+		cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = &stdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			return "", fmt.Errorf("could not run %q: %w", args[0], err)
+		}
+
+		return strings.TrimSpace(stdout.String()), nil
+	}
+
+	return value, nil
+}
+
+// ResolveTokens replaces the token references of github and githubtestorg with the secrets they
+// point to. Context tokens are resolved when the context is selected.
+func (s *Spec) ResolveTokens(ctx context.Context) error {
+	var err error
+
+	walk("", reflect.ValueOf(s).Elem(), func(key string, field reflect.Value) {
+		if err != nil || !strings.HasSuffix(key, ".token") {
+			return
+		}
+
+		var token string
+		if token, err = s.resolveToken(ctx, key, field.String()); err == nil {
+			field.SetString(token)
+		}
+	})
+
+	return err
+}
+
+// resolveToken returns the secret a token references. Literal tokens are only accepted from
+// configuration files that can't be read by other users.
+func (s *Spec) resolveToken(ctx context.Context, key string, token string) (string, error) {
+	if token == "" {
+		return "", nil
+	}
+
+	if !IsSecretReference(token) {
+		switch s.Source(key) {
+		case SourceFile:
+			return token, checkPrivate(s.file, key)
+		case SourceInclude:
+			origin := s.Origin(key)
+			if strings.HasPrefix(origin, gitIncludePrefix) {
+				return "", fmt.Errorf("%s holds a literal token in %s, shared configurations must use a reference such as \"env:GITHUB_TOKEN\"", origin, key)
+			}
+
+			return token, checkPrivate(origin, key)
+		}
+
+		return token, nil
+	}
+
+	secret, err := ResolveSecret(ctx, token)
+	if err != nil {
+		return "", fmt.Errorf("could not resolve %s: %w", key, err)
+	}
+
+	return secret, nil
+}
+
+// checkPrivate fails if the file is readable by its group or by others
+func checkPrivate(file string, key string) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+
+	info, err := os.Stat(file)
+	if err != nil {
+		return err
+	}
+
+	if info.Mode().Perm()&0044 != 0 {
+		return fmt.Errorf(
+			"%s holds a literal token in %s but is readable by other users (%s), run `chmod 600 %s` or use a reference such as \"env:GITHUB_TOKEN\"",
+			file, key, info.Mode().Perm(), file,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveSecret(t *testing.T) {
+	dir := t.TempDir()
+	secretFile := filepath.Join(dir, "token")
+	require.NoError(t, os.WriteFile(secretFile, []byte("  file-secret\n"), 0600))
+	t.Setenv("GHCLI_TEST_SECRET", "env-secret")
+	t.Setenv("GHCLI_TEST_EMPTY", "")
+
+	tests := []struct {
+		name   string
+		value  string
+		secret string
+		err    string
+	}{
+		{name: "literal", value: "ghp_literal", secret: "ghp_literal"},
+		{name: "unknown scheme", value: "vault:secret/github", secret: "vault:secret/github"},
+		{name: "environment variable", value: "env:GHCLI_TEST_SECRET", secret: "env-secret"},
+		{name: "unset environment variable", value: "env:GHCLI_TEST_MISSING", err: "environment variable GHCLI_TEST_MISSING is not set"},
+		{name: "empty environment variable", value: "env:GHCLI_TEST_EMPTY", err: "environment variable GHCLI_TEST_EMPTY is not set"},
+		{name: "file", value: "file:" + secretFile, secret: "file-secret"},
+		{name: "missing file", value: "file:" + filepath.Join(dir, "missing"), err: "could not read secret file"},
+		{name: "command", value: "cmd:echo  cmd-secret ", secret: "cmd-secret"},
+		{name: "failing command", value: "cmd:false", err: `could not run "false"`},
+		{name: "empty command", value: "cmd: ", err: "no command given"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if runtime.GOOS == "windows" && tt.name == "command" {
+				t.Skip("echo is a shell builtin on windows")
+			}
+
+			secret, err := ResolveSecret(context.Background(), tt.value)
+			if tt.err != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.secret, secret)
+		})
+	}
+}
+
+func TestIsSecretReference(t *testing.T) {
+	assert.True(t, IsSecretReference("env:GITHUB_TOKEN"))
+	assert.True(t, IsSecretReference("file:~/.github-token"))
+	assert.True(t, IsSecretReference("cmd:pass show github"))
+	assert.False(t, IsSecretReference("ghp_literal"))
+	assert.False(t, IsSecretReference("vault:secret/github"))
+}
+
+func TestResolveTokensLiteralPermissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file permissions are not checked on windows")
+	}
+
+	tests := []struct {
+		name string
+		perm os.FileMode
+		err  bool
+	}{
+		{name: "private", perm: 0600},
+		{name: "read only by the owner", perm: 0400},
+		{name: "executable by others", perm: 0711},
+		{name: "writable by the group", perm: 0620},
+		{name: "readable by the group", perm: 0640, err: true},
+		{name: "readable by others", perm: 0604, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GITHUB_TOKEN", "")
+			file := writeConfig(t, "toml", "[github]\nToken = \"ghp_literal\"\n")
+			require.NoError(t, os.Chmod(file, tt.perm))
+
+			spec, err := Read(context.Background(), file)
+			require.NoError(t, err)
+
+			err = spec.ResolveTokens(context.Background())
+			if !tt.err {
+				require.NoError(t, err)
+				assert.Equal(t, "ghp_literal", spec.Github.Token)
+				return
+			}
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "holds a literal token in github.token but is readable by other users")
+		})
+	}
+}
+
+func TestResolveTokensReferences(t *testing.T) {
+	t.Setenv("GHCLI_TEST_TOKEN", "ghp_from_env")
+	file := writeConfig(t, "toml", "[github]\nToken = \"env:GHCLI_TEST_TOKEN\"\n[githubtestorg]\nToken = \"env:GHCLI_TEST_MISSING\"\n")
+	// references may be shared, the permissions of the file do not matter
+	require.NoError(t, os.Chmod(file, 0644))
+
+	spec, err := Read(context.Background(), file)
+	require.NoError(t, err)
+
+	err = spec.ResolveTokens(context.Background())
+	assert.EqualError(t, err, "could not resolve githubtestorg.token: environment variable GHCLI_TEST_MISSING is not set")
+	assert.Equal(t, "ghp_from_env", spec.Github.Token)
+}
@@ -0,0 +1,160 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// Source is where a configuration value comes from
+type Source string
+
+// Value is a single effective configuration value and the place it was set
+type Value struct {
+	Key    string
+	Value  interface{}
+	Source Source
+}
+
+const (
+	// SourceDefault is used for values that were not set anywhere
+	SourceDefault Source = "default"
+	// SourceFile is used for values read from the configuration file
+	SourceFile Source = "file"
+	// SourceInclude is used for values read from an included configuration file
+	SourceInclude Source = "include"
+	// SourceEnv is used for values read from environment variables
+	SourceEnv Source = "env"
+	// SourceFlag is used for values given as command line flags
+	SourceFlag Source = "flag"
+)
+
+// secretKeys are keys whose values are never displayed
+var secretKeys = map[string]bool{
+	"token":  true,
+	"secret": true,
+}
+
+// Source returns where the value of a key, e.g. "github.token", comes from
+func (s *Spec) Source(key string) Source {
+	if source, ok := s.sources[strings.ToLower(key)]; ok {
+		return source
+	}
+
+	return SourceDefault
+}
+
+// SetSource records where the value of a key comes from
+func (s *Spec) SetSource(key string, source Source) {
+	if s.sources == nil {
+		s.sources = make(map[string]Source)
+	}
+
+	s.sources[strings.ToLower(key)] = source
+}
+
+// Origin returns the included file the value of a key comes from, if any
+func (s *Spec) Origin(key string) string {
+	return s.origins[strings.ToLower(key)]
+}
+
+// setOrigin records where the value of a key comes from and the file holding it
+func (s *Spec) setOrigin(key string, source Source, origin string) {
+	s.SetSource(key, source)
+
+	if source != SourceInclude {
+		return
+	}
+
+	if s.origins == nil {
+		s.origins = make(map[string]string)
+	}
+	s.origins[strings.ToLower(key)] = origin
+}
+
+// Values returns all the effective configuration values in the order they are declared in Spec
+func (s *Spec) Values() []Value {
+	var values []Value
+
+	walk("", reflect.ValueOf(s).Elem(), func(key string, field reflect.Value) {
+		values = append(values, Value{Key: key, Value: field.Interface(), Source: s.Source(key)})
+	})
+
+	return values
+}
+
+// Redacted returns the value with tokens and secrets masked, so it can be safely displayed
+func (v Value) Redacted() interface{} {
+	if secretKeys[v.Key[strings.LastIndex(v.Key, ".")+1:]] {
+		token, _ := v.Value.(string)
+		return Redact(token)
+	}
+
+	b, err := json.Marshal(v.Value)
+	if err != nil {
+		return v.Value
+	}
+
+	var generic interface{}
+	if err := json.Unmarshal(b, &generic); err != nil {
+		return v.Value
+	}
+
+	return redactSecrets(generic)
+}
+
+// Redact masks a token keeping only enough of it to tell tokens apart
+func Redact(token string) string {
+	if token == "" {
+		return ""
+	}
+
+	if len(token) < 20 {
+		return "****"
+	}
+
+	return "****" + token[len(token)-4:]
+}
+
+func redactSecrets(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for key, inner := range value {
+			if secret, ok := inner.(string); ok && secretKeys[strings.ToLower(key)] {
+				value[key] = Redact(secret)
+			} else {
+				value[key] = redactSecrets(inner)
+			}
+		}
+	case []interface{}:
+		for i, inner := range value {
+			value[i] = redactSecrets(inner)
+		}
+	}
+
+	return v
+}
+
+// walk calls fn for every configuration key and its field, descending into nested structs
+func walk(prefix string, v reflect.Value, fn func(key string, field reflect.Value)) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			// unexported
+			continue
+		}
+
+		key := strings.ToLower(field.Name)
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		if field.Type.Kind() == reflect.Struct {
+			walk(key, v.Field(i), fn)
+			continue
+		}
+
+		fn(key, v.Field(i))
+	}
+}
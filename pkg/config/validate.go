@@ -0,0 +1,277 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+// FieldError describes an invalid value in the configuration
+type FieldError struct {
+	File    string
+	Key     string
+	Message string
+}
+
+var (
+	// permissions are the permissions github accepts for teams and collaborators
+	permissions = []string{"pull", "triage", "push", "maintain", "admin"}
+	colorRegexp = regexp.MustCompile(`^[0-9a-fA-F]{6}$`)
+	topicRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,49}$`)
+)
+
+// Error implements the error interface
+func (e *FieldError) Error() string {
+	if e.File == "" {
+		return fmt.Sprintf("%s: %s", e.Key, e.Message)
+	}
+
+	return fmt.Sprintf("%s: %s: %s", e.File, e.Key, e.Message)
+}
+
+// Validate checks the configuration for values that would otherwise only be rejected by the
+// github API. All the problems found are reported at once as FieldErrors.
+func (s *Spec) Validate() error {
+	var err error
+
+	report := func(key string, format string, args ...interface{}) {
+		err = multierror.Append(err, &FieldError{
+			File:    s.location(key),
+			Key:     key,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	for _, key := range s.unknown {
+		report(key, "unknown configuration key")
+	}
+
+	// a selected context was copied into Github and is validated on its own below
+	if s.context == "" {
+		if s.Github.Organization == "" {
+			report("github.organization", "organization must not be empty, set it or select one of the contexts")
+		}
+
+		validateGithub("github", &s.Github, report)
+	}
+
+	validateGithub("githubtestorg", &s.GithubTestOrg, report)
+
+	if _, ok := s.Contexts[strings.ToLower(s.CurrentContext)]; s.CurrentContext != "" && !ok {
+		report("currentcontext", "context %q is not defined", s.CurrentContext)
+	}
+
+	switch s.Update.Channel {
+	case "", ChannelStable, ChannelPrerelease:
+	default:
+		report("update.channel", "invalid channel %q, expected %s or %s", s.Update.Channel, ChannelStable, ChannelPrerelease)
+	}
+
+	for _, name := range s.ContextNames() {
+		prefix := "contexts." + name
+		gh := s.Contexts[name]
+		if gh == nil {
+			report(prefix, "context must not be empty")
+			continue
+		}
+
+		if gh.Organization == "" {
+			report(prefix+".organization", "organization must not be empty")
+		}
+
+		validateGithub(prefix, gh, report)
+	}
+
+	return err
+}
+
+func validateGithub(prefix string, gh *Github, report func(key string, format string, args ...interface{})) {
+	for i, team := range gh.Teams {
+		key := fmt.Sprintf("%s.teams[%d]", prefix, i)
+		if team == nil {
+			report(key, "team must not be empty")
+			continue
+		}
+
+		if team.ID <= 0 {
+			report(key+".id", "team ID must be a positive number, got %d", team.ID)
+		}
+		if !isPermission(team.Permission) {
+			report(key+".permission", "invalid permission %q, expected one of %s", team.Permission, strings.Join(permissions, ", "))
+		}
+	}
+
+	for i, collaborator := range gh.Collaborators {
+		key := fmt.Sprintf("%s.collaborators[%d]", prefix, i)
+		if collaborator == nil {
+			report(key, "collaborator must not be empty")
+			continue
+		}
+
+		if collaborator.Username == "" {
+			report(key+".username", "username must not be empty")
+		}
+		if !isPermission(collaborator.Permission) {
+			report(key+".permission", "invalid permission %q, expected one of %s", collaborator.Permission, strings.Join(permissions, ", "))
+		}
+	}
+
+	labels := make(map[string]int)
+	for i, label := range gh.Labels {
+		key := fmt.Sprintf("%s.labels[%d]", prefix, i)
+		if label == nil {
+			report(key, "label must not be empty")
+			continue
+		}
+
+		if label.Name == "" {
+			report(key+".name", "label name must not be empty")
+		} else if first, ok := labels[strings.ToLower(label.Name)]; ok {
+			report(key+".name", "label %q is already defined at %s.labels[%d]", label.Name, prefix, first)
+		} else {
+			labels[strings.ToLower(label.Name)] = i
+		}
+
+		if !colorRegexp.MatchString(label.Color) {
+			report(key+".color", "invalid color %q, expected a 6 digit hex value without '#' like \"b60205\"", label.Color)
+		}
+
+		for j, alias := range label.Aliases {
+			if strings.TrimSpace(alias) == "" {
+				report(fmt.Sprintf("%s.aliases[%d]", key, j), "alias must not be empty")
+			}
+		}
+	}
+
+	// an alias of a configured label would rename it away
+	for i, label := range gh.Labels {
+		if label == nil {
+			continue
+		}
+
+		for j, alias := range label.Aliases {
+			if other, ok := labels[strings.ToLower(alias)]; ok && other != i {
+				report(fmt.Sprintf("%s.labels[%d].aliases[%d]", prefix, i, j), "alias %q is the name of %s.labels[%d]", alias, prefix, other)
+			}
+		}
+	}
+
+	hooks := make(map[string]int)
+	for i, webhook := range gh.Webhooks {
+		key := fmt.Sprintf("%s.webhooks[%d]", prefix, i)
+		if webhook == nil {
+			report(key, "webhook must not be empty")
+			continue
+		}
+
+		if webhook.URL() == "" {
+			report(key+".config.url", "webhook url must not be empty")
+		} else if first, ok := hooks[webhook.URL()]; ok {
+			report(key+".config.url", "webhook %q is already defined at %s.webhooks[%d]", webhook.URL(), prefix, first)
+		} else {
+			hooks[webhook.URL()] = i
+		}
+
+		if webhook.Type != "" && webhook.Type != "web" {
+			report(key+".type", "invalid type %q, github only supports \"web\"", webhook.Type)
+		}
+
+		if contentType, ok := webhook.Config["content_type"]; ok && contentType != "json" && contentType != "form" {
+			report(key+".config.content_type", "invalid content type %v, expected json or form", contentType)
+		}
+
+		if insecure, ok := webhook.Config["insecure_ssl"]; ok {
+			switch fmt.Sprint(insecure) {
+			case "0", "1", "true", "false":
+			default:
+				report(key+".config.insecure_ssl", "invalid insecure_ssl %v, expected \"0\" or \"1\"", insecure)
+			}
+		}
+
+		for j, event := range webhook.Events {
+			if strings.TrimSpace(event) == "" {
+				report(fmt.Sprintf("%s.events[%d]", key, j), "event must not be empty")
+			}
+		}
+	}
+
+	if gh.BaseURL != "" {
+		if u, err := url.Parse(gh.BaseURL); err != nil || u.Scheme == "" || u.Host == "" {
+			report(prefix+".baseurl", "invalid base URL %q, expected something like \"https://github.example.com/api/v3/\"", gh.BaseURL)
+		}
+	}
+
+	validateSettings(prefix+".settings", &gh.Settings, report)
+
+	for branch := range gh.Protections {
+		if strings.TrimSpace(branch) == "" {
+			report(prefix+".protections", "branch name must not be empty")
+		}
+	}
+}
+
+// location returns where the value of a key was set, so problems point at the right place
+func (s *Spec) location(key string) string {
+	for path := key; ; {
+		switch s.sources[path] {
+		case SourceEnv:
+			return "$" + EnvVar(path)
+		case SourceFlag:
+			return "command line flag"
+		case SourceInclude:
+			return s.origins[path]
+		}
+
+		i := strings.LastIndexAny(path, ".[")
+		if i < 0 {
+			return s.file
+		}
+		path = path[:i]
+	}
+}
+
+func validateSettings(prefix string, settings *RepositorySettings, report func(key string, format string, args ...interface{})) {
+	switch settings.Visibility {
+	case "", "public", "private", "internal":
+	default:
+		report(prefix+".visibility", "invalid visibility %q, expected public, private or internal", settings.Visibility)
+	}
+
+	for i, topic := range settings.Topics {
+		if !topicRegexp.MatchString(topic) {
+			report(fmt.Sprintf("%s.topics[%d]", prefix, i), "invalid topic %q, expected up to 50 lowercase letters, numbers and hyphens", topic)
+		}
+	}
+
+	if settings.DefaultBranch != "" && strings.TrimSpace(settings.DefaultBranch) != settings.DefaultBranch {
+		report(prefix+".defaultbranch", "invalid branch name %q", settings.DefaultBranch)
+	}
+
+	if settings.DependabotSecurityUpdates != nil && *settings.DependabotSecurityUpdates &&
+		settings.VulnerabilityAlerts != nil && !*settings.VulnerabilityAlerts {
+		report(prefix+".dependabotsecurityupdates", "dependabot security updates require vulnerability alerts")
+	}
+
+	disabled := 0
+	for _, allowed := range []*bool{settings.AllowMergeCommit, settings.AllowSquashMerge, settings.AllowRebaseMerge} {
+		if allowed != nil && !*allowed {
+			disabled++
+		}
+	}
+	if disabled == 3 {
+		report(prefix, "at least one of the merge methods must be allowed")
+	}
+}
+
+func isPermission(permission string) bool {
+	for _, p := range permissions {
+		if p == permission {
+			return true
+		}
+	}
+
+	return false
+}
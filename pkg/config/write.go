@@ -0,0 +1,26 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+)
+
+// Write stores the configuration in the format given by the file extension. The file is only
+// readable by the current user as it holds the github tokens.
+func Write(path string, spec *Spec) error {
+	format, err := FormatOf(path)
+	if err != nil {
+		return err
+	}
+
+	b, err := Marshal(spec, format)
+	if err != nil {
+		return fmt.Errorf("could not marshal configuration: %w", err)
+	}
+
+	if err := ioutil.WriteFile(path, b, 0600); err != nil {
+		return fmt.Errorf("could not write configuration file: %w", err)
+	}
+
+	return nil
+}
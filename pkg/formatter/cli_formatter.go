@@ -3,8 +3,12 @@ package formatter
 import (
 	"bytes"
 	"fmt"
+	"io"
+	"os"
+	"sort"
 
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/term"
 )
 
 // colors.
@@ -36,7 +40,27 @@ var Strings = [...]string{
 }
 
 // CliFormatter is a CLI formatter for logrus
-type CliFormatter struct{}
+type CliFormatter struct {
+	// DisableColors leaves out the ANSI color codes
+	DisableColors bool
+}
+
+// NewCliFormatter creates a formatter for the given output, colored only when it is a terminal
+// and NO_COLOR is not set
+func NewCliFormatter(out io.Writer) *CliFormatter {
+	return &CliFormatter{DisableColors: !ColorsEnabled(out)}
+}
+
+// ColorsEnabled checks if ANSI colors can be written to out, see https://no-color.org
+func ColorsEnabled(out io.Writer) bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+
+	f, ok := out.(*os.File)
+
+	return ok && term.IsTerminal(int(f.Fd()))
+}
 
 // Format renders a single log entry
 func (f *CliFormatter) Format(e *log.Entry) ([]byte, error) {
@@ -54,15 +78,24 @@ func (f *CliFormatter) Format(e *log.Entry) ([]byte, error) {
 	for k := range e.Data {
 		keys = append(keys, k)
 	}
+	sort.Strings(keys)
 
-	fmt.Fprintf(b, "\033[%dm%*s\033[0m %-25s", color, 1, level, e.Message)
+	if f.DisableColors {
+		fmt.Fprintf(b, "%*s %-25s", 1, level, e.Message)
+	} else {
+		fmt.Fprintf(b, "\033[%dm%*s\033[0m %-25s", color, 1, level, e.Message)
+	}
 
 	for _, key := range keys {
 		if key == "source" {
 			continue
 		}
 
-		fmt.Fprintf(b, " \033[%dm%s\033[0m=%v", color, key, e.Data[key])
+		if f.DisableColors {
+			fmt.Fprintf(b, " %s=%v", key, e.Data[key])
+		} else {
+			fmt.Fprintf(b, " \033[%dm%s\033[0m=%v", color, key, e.Data[key])
+		}
 	}
 
 	fmt.Fprintln(b)
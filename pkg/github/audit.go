@@ -0,0 +1,182 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	homedir "github.com/mitchellh/go-homedir"
+
+	"github.com/hellofresh/github-cli/pkg/audit"
+	"github.com/hellofresh/github-cli/pkg/config"
+	"github.com/hellofresh/github-cli/pkg/log"
+)
+
+// enterprisePrefix is the root of the API on GitHub Enterprise servers
+const enterprisePrefix = "/api/v3"
+
+// auditTransport appends the mutating calls to the audit log
+type auditTransport struct {
+	ctx       context.Context
+	transport http.RoundTripper
+	token     string
+
+	once  sync.Once
+	login string
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *auditTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !audit.Mutating(req.Method) {
+		return t.transport.RoundTrip(req)
+	}
+
+	path := strings.TrimPrefix(req.URL.Path, enterprisePrefix)
+	org, repo := audit.Target(path)
+	if org != "" && repo == "" {
+		repo = createdRepo(req)
+	}
+
+	// the owner of the token is looked up first, so the call is attributed even if it breaks the token
+	entry := &audit.Entry{
+		User:    t.user(req),
+		Command: t.commandLine(),
+		Org:     org,
+		Repo:    repo,
+		Action:  audit.Action(req.Method, path),
+		Method:  req.Method,
+		Path:    path,
+		Outcome: audit.OutcomeSuccess,
+	}
+
+	resp, err := t.transport.RoundTrip(req)
+	entry.Time = time.Now().UTC()
+
+	switch {
+	case err != nil:
+		entry.Outcome = audit.OutcomeFailure
+		entry.Error = err.Error()
+	case resp.StatusCode >= 400:
+		entry.Status = resp.StatusCode
+		entry.Outcome = audit.OutcomeFailure
+		entry.Error = errorMessage(resp)
+	default:
+		entry.Status = resp.StatusCode
+	}
+
+	t.append(entry)
+
+	return resp, err
+}
+
+func (t *auditTransport) append(entry *audit.Entry) {
+	logger := log.WithContext(t.ctx)
+
+	file := ""
+	if cfg := config.WithContext(t.ctx); cfg != nil {
+		file = cfg.AuditLog
+	}
+	var err error
+	if file == "" {
+		file, err = audit.DefaultFile()
+	} else {
+		file, err = homedir.Expand(file)
+	}
+	if err != nil {
+		logger.WithError(err).Warn("Could not find the audit log")
+		return
+	}
+
+	if err := audit.NewLog(file).Append(entry); err != nil {
+		logger.WithError(err).Warn("Could not record the call in the audit log")
+	}
+}
+
+// user returns the login of the owner of the token, looked up once
+func (t *auditTransport) user(req *http.Request) string {
+	t.once.Do(func() {
+		u := *req.URL
+		u.Path = "/user"
+		if strings.HasPrefix(req.URL.Path, enterprisePrefix+"/") {
+			u.Path = enterprisePrefix + "/user"
+		}
+		u.RawQuery = ""
+
+		userReq, err := http.NewRequestWithContext(req.Context(), http.MethodGet, u.String(), nil)
+		if err != nil {
+			return
+		}
+		userReq.Header.Set("Accept", "application/vnd.github.v3+json")
+
+		resp, err := t.transport.RoundTrip(userReq)
+		if err != nil {
+			log.WithContext(t.ctx).WithError(err).Debug("Could not find the owner of the token")
+			return
+		}
+		defer resp.Body.Close()
+
+		var user struct {
+			Login string `json:"login"`
+		}
+		if resp.StatusCode == http.StatusOK && json.NewDecoder(resp.Body).Decode(&user) == nil {
+			t.login = user.Login
+		}
+	})
+
+	return t.login
+}
+
+// commandLine returns how github-cli was called, without the token
+func (t *auditTransport) commandLine() string {
+	commandLine := strings.Join(os.Args, " ")
+	if t.token != "" {
+		commandLine = strings.ReplaceAll(commandLine, t.token, "REDACTED")
+	}
+
+	return commandLine
+}
+
+// createdRepo returns the name of a repository being created, from the body of the request
+func createdRepo(req *http.Request) string {
+	if req.GetBody == nil {
+		return ""
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+
+	var repo struct {
+		Name string `json:"name"`
+	}
+	json.NewDecoder(body).Decode(&repo)
+
+	return repo.Name
+}
+
+// errorMessage returns the message of a github error response, keeping the body readable
+func errorMessage(resp *http.Response) string {
+	b, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(b))
+	if err != nil {
+		return resp.Status
+	}
+
+	var ghErr struct {
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(b, &ghErr) != nil || ghErr.Message == "" {
+		return resp.Status
+	}
+
+	return ghErr.Message
+}
@@ -0,0 +1,114 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hellofresh/github-cli/pkg/audit"
+	"github.com/hellofresh/github-cli/pkg/config"
+	"github.com/hellofresh/github-cli/pkg/log"
+)
+
+func TestAuditTransport(t *testing.T) {
+	var users int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v3/user":
+			atomic.AddInt32(&users, 1)
+			w.Write([]byte(`{"login":"alice"}`))
+		case "POST /api/v3/orgs/hellofresh/repos":
+			w.WriteHeader(http.StatusCreated)
+		case "DELETE /api/v3/repos/hellofresh/old":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"Not Found"}`))
+		default:
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	args := os.Args
+	os.Args = []string{"github-cli", "repo", "create", "svc", "--token", "ghp_token"}
+	defer func() { os.Args = args }()
+
+	file := filepath.Join(t.TempDir(), "audit.jsonl")
+	ctx := config.OverrideConfig(log.NewContext(context.Background()), &config.Spec{AuditLog: file})
+	client := &http.Client{Transport: &auditTransport{ctx: ctx, transport: http.DefaultTransport, token: "ghp_token"}}
+
+	do := func(method string, path string, body string) string {
+		t.Helper()
+
+		req, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+		require.NoError(t, err)
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		b, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		return string(b)
+	}
+
+	do(http.MethodGet, "/api/v3/repos/hellofresh/svc", "")
+	do(http.MethodPost, "/api/v3/orgs/hellofresh/repos", `{"name":"svc","private":true}`)
+	do(http.MethodGet, "/api/v3/repos/hellofresh/svc", "")
+	body := do(http.MethodDelete, "/api/v3/repos/hellofresh/old", "")
+	assert.Equal(t, `{"message":"Not Found"}`, body, "the body of a failed call is kept readable")
+
+	b, err := ioutil.ReadFile(file)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(string(b), "\n"), "\n")
+	require.Len(t, lines, 2, "one line per mutating request, GETs are skipped")
+
+	var entries []*audit.Entry
+	for _, line := range lines {
+		var entry audit.Entry
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		assert.False(t, entry.Time.IsZero())
+		entry.Time = entry.Time.UTC().Truncate(0)
+		entries = append(entries, &entry)
+	}
+
+	command := "github-cli repo create svc --token REDACTED"
+	assert.Equal(t, &audit.Entry{
+		Time:    entries[0].Time,
+		User:    "alice",
+		Command: command,
+		Org:     "hellofresh",
+		Repo:    "svc",
+		Action:  "repo.create",
+		Method:  http.MethodPost,
+		Path:    "/orgs/hellofresh/repos",
+		Status:  http.StatusCreated,
+		Outcome: audit.OutcomeSuccess,
+	}, entries[0])
+	assert.Equal(t, &audit.Entry{
+		Time:    entries[1].Time,
+		User:    "alice",
+		Command: command,
+		Org:     "hellofresh",
+		Repo:    "old",
+		Action:  "repo.destroy",
+		Method:  http.MethodDelete,
+		Path:    "/repos/hellofresh/old",
+		Status:  http.StatusNotFound,
+		Outcome: audit.OutcomeFailure,
+		Error:   "Not Found",
+	}, entries[1])
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&users), "the owner of the token is looked up once")
+}
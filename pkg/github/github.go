@@ -2,23 +2,101 @@ package github
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
 
 	"github.com/google/go-github/v33/github"
 	"golang.org/x/oauth2"
+
+	"github.com/hellofresh/github-cli/internal/fixture"
 )
 
-type githubKeyType int
+type (
+	githubKeyType int
+
+	// holder allows the client to be replaced once the command line flags are parsed,
+	// while commands keep the context they were created with
+	holder struct {
+		client *github.Client
+		// record writes the interactions to a fixture file
+		record *fixture.Recorder
+		// replay answers with the interactions of a fixture file instead of github
+		replay *fixture.Replayer
+		// secrets are scrubbed from the recorded interactions along with the token
+		secrets []string
+	}
+)
 
 const githubKey githubKeyType = iota
 
-// NewContext returns a context with the github client imported
+const (
+	// RecordEnv names the fixture file where the API interactions are recorded, with the token scrubbed
+	RecordEnv = "GITHUB_CLI_RECORD"
+	// ReplayEnv names the fixture file whose interactions are replayed instead of calling the API
+	ReplayEnv = "GITHUB_CLI_REPLAY"
+)
+
+var errNoClient = errors.New("no github client found in context")
+
+// NewContext returns a context with the github client imported. When the GITHUB_CLI_RECORD environment
+// variable is set the API interactions are recorded to that fixture file, when GITHUB_CLI_REPLAY is set
+// the interactions of that fixture file are served instead of calling the API. Git operations, such as
+// cloning, are not recorded.
 func NewContext(ctx context.Context, token string) (context.Context, error) {
-	ts := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: token},
-	)
-	tc := oauth2.NewClient(ctx, ts)
+	h := &holder{}
+	if file := os.Getenv(RecordEnv); file != "" {
+		h.record = fixture.NewRecorder(file)
+	}
+
+	if file := os.Getenv(ReplayEnv); file != "" {
+		interactions, err := fixture.Load(file)
+		if err != nil {
+			return ctx, fmt.Errorf("could not replay %s: %w", file, err)
+		}
 
-	return context.WithValue(ctx, githubKey, github.NewClient(tc)), nil
+		h.replay = fixture.NewReplayer(interactions)
+	}
+
+	client, err := h.newClient(ctx, token, "")
+	if err != nil {
+		return ctx, err
+	}
+	h.client = client
+
+	return context.WithValue(ctx, githubKey, h), nil
+}
+
+// Authenticate replaces the github client of the context with one that uses the given token.
+// An empty baseURL means github.com, otherwise it points at a GitHub Enterprise server. The secrets,
+// e.g. other tokens of the configuration, are scrubbed from the recorded interactions.
+func Authenticate(ctx context.Context, token string, baseURL string, secrets ...string) error {
+	h, ok := ctx.Value(githubKey).(*holder)
+	if !ok {
+		return errNoClient
+	}
+	h.secrets = append(h.secrets, secrets...)
+
+	client, err := h.newClient(ctx, token, baseURL)
+	if err != nil {
+		return err
+	}
+
+	h.client = client
+
+	return nil
+}
+
+// NewClient returns a client using the given token without replacing the one of the context, e.g. to
+// read the github-cli releases on github.com while the configuration points at an enterprise server.
+func NewClient(ctx context.Context, token string, baseURL string) (*github.Client, error) {
+	h, ok := ctx.Value(githubKey).(*holder)
+	if !ok {
+		return nil, errNoClient
+	}
+
+	return h.newClient(ctx, token, baseURL)
 }
 
 // WithContext returns a github client from the context
@@ -27,9 +105,36 @@ func WithContext(ctx context.Context) *github.Client {
 		return nil
 	}
 
-	if ctxKube, ok := ctx.Value(githubKey).(*github.Client); ok {
-		return ctxKube
+	if h, ok := ctx.Value(githubKey).(*holder); ok {
+		return h.client
 	}
 
 	return nil
 }
+
+func (h *holder) newClient(ctx context.Context, token string, baseURL string) (*github.Client, error) {
+	transport := http.DefaultTransport
+	if h.replay != nil {
+		transport = h.replay
+	}
+	if token != "" {
+		transport = &oauth2.Transport{
+			Source: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token}),
+			Base:   transport,
+		}
+	}
+
+	if h.record != nil {
+		transport = h.record.Wrap(transport, append([]string{token}, h.secrets...)...)
+	}
+	if h.replay == nil {
+		transport = &auditTransport{ctx: ctx, transport: transport, token: token}
+	}
+	tc := &http.Client{Transport: &loggingTransport{ctx: ctx, transport: transport}}
+
+	if baseURL == "" {
+		return github.NewClient(tc), nil
+	}
+
+	return github.NewEnterpriseClient(baseURL, baseURL, tc)
+}
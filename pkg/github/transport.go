@@ -0,0 +1,41 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/hellofresh/github-cli/pkg/log"
+)
+
+// loggingTransport logs every request to the API at debug level, with the request ID github
+// returns so problems can be reported to GitHub support
+type loggingTransport struct {
+	ctx       context.Context
+	transport http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.transport.RoundTrip(req)
+
+	entry := log.WithContext(t.ctx).WithFields(logrus.Fields{
+		"method":          req.Method,
+		"path":            req.URL.Path,
+		log.FieldDuration: time.Since(start).Seconds(),
+	})
+	if err != nil {
+		entry.WithError(err).Debug("GitHub request failed")
+		return resp, err
+	}
+
+	entry.WithFields(logrus.Fields{
+		"status":           resp.StatusCode,
+		log.FieldRequestID: resp.Header.Get("X-GitHub-Request-Id"),
+	}).Debug("GitHub request")
+
+	return resp, nil
+}
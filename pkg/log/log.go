@@ -2,7 +2,9 @@ package log
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"strings"
 
 	"github.com/hellofresh/github-cli/pkg/formatter"
 	"github.com/sirupsen/logrus"
@@ -12,12 +14,30 @@ type loggerKeyType int
 
 const loggerKey loggerKeyType = iota
 
+// Field names shared by the commands, kept stable so json and logfmt logs can be parsed
+const (
+	FieldCommand   = "command"
+	FieldOrg       = "org"
+	FieldRepo      = "repo"
+	FieldStep      = "step"
+	FieldDuration  = "duration"
+	FieldRequestID = "request_id"
+)
+
+// Formats are the supported log formats
+var Formats = []string{"text", "json", "logfmt"}
+
 var logger logrus.Logger
 
+// fieldsHook adds fields to every entry
+type fieldsHook struct {
+	fields logrus.Fields
+}
+
 func init() {
 	logger = logrus.Logger{
 		Out:       os.Stderr,
-		Formatter: &formatter.CliFormatter{},
+		Formatter: formatter.NewCliFormatter(os.Stderr),
 		Hooks:     make(logrus.LevelHooks),
 		Level:     logrus.InfoLevel,
 	}
@@ -40,3 +60,90 @@ func WithContext(ctx context.Context) *logrus.Logger {
 
 	return &logger
 }
+
+// Configure sets the format of the logger of the context, text, json or logfmt. The logs are appended
+// to file instead of written to stderr when it is given. The returned func closes the file and
+// switches the logger back to stderr, it must be called once the command is done.
+func Configure(ctx context.Context, format string, file string) (func() error, error) {
+	l := WithContext(ctx)
+	closeFile := func() error { return nil }
+
+	switch format {
+	case "", "text", "json", "logfmt":
+	default:
+		return closeFile, fmt.Errorf("unsupported log format %q, expected one of %s", format, strings.Join(Formats, ", "))
+	}
+
+	if file != "" {
+		f, err := os.OpenFile(file, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		if err != nil {
+			return closeFile, fmt.Errorf("could not open log file: %w", err)
+		}
+		l.SetOutput(f)
+
+		closeFile = func() error {
+			l.SetOutput(os.Stderr)
+			return f.Close()
+		}
+	}
+
+	switch format {
+	case "", "text":
+		l.SetFormatter(formatter.NewCliFormatter(l.Out))
+		return closeFile, nil
+	case "json":
+		l.SetFormatter(&logrus.JSONFormatter{})
+	case "logfmt":
+		l.SetFormatter(&logrus.TextFormatter{DisableColors: true, FullTimestamp: true})
+	}
+
+	if findFieldsHook(l) == nil {
+		l.AddHook(&fieldsHook{fields: make(logrus.Fields)})
+	}
+
+	return closeFile, nil
+}
+
+// AddFields adds fields to every entry of the logger of the context, e.g. the command and organization.
+// Only json and logfmt logs get them, the text logs are meant for people following a command.
+func AddFields(ctx context.Context, fields logrus.Fields) {
+	hook := findFieldsHook(WithContext(ctx))
+	if hook == nil {
+		return
+	}
+
+	for key, value := range fields {
+		hook.fields[key] = value
+	}
+}
+
+func findFieldsHook(l *logrus.Logger) *fieldsHook {
+	for _, hook := range l.Hooks[logrus.InfoLevel] {
+		if h, ok := hook.(*fieldsHook); ok {
+			return h
+		}
+	}
+
+	return nil
+}
+
+// Levels implements logrus.Hook
+func (h *fieldsHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire implements logrus.Hook, fields of the entry take precedence
+func (h *fieldsHook) Fire(e *logrus.Entry) error {
+	for key, value := range h.fields {
+		if _, ok := e.Data[key]; !ok {
+			e.Data[key] = value
+		}
+	}
+
+	return nil
+}
+
+// AddField adds a field to every entry of the logger of the context, see AddFields
+func AddField(ctx context.Context, key string, value interface{}) {
+	AddFields(ctx, logrus.Fields{key: value})
+}
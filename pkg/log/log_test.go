@@ -0,0 +1,131 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hellofresh/github-cli/pkg/formatter"
+)
+
+// newLoggerContext returns a context with its own logger, so the tests do not configure the global one
+func newLoggerContext() (context.Context, *logrus.Logger) {
+	l := logrus.New()
+	l.SetOutput(ioutil.Discard)
+
+	return context.WithValue(context.Background(), loggerKey, l), l
+}
+
+func TestConfigure(t *testing.T) {
+	tests := []struct {
+		format    string
+		formatter logrus.Formatter
+		fields    bool
+	}{
+		{format: "", formatter: &formatter.CliFormatter{DisableColors: true}},
+		{format: "text", formatter: &formatter.CliFormatter{DisableColors: true}},
+		{format: "json", formatter: &logrus.JSONFormatter{}, fields: true},
+		{format: "logfmt", formatter: &logrus.TextFormatter{DisableColors: true, FullTimestamp: true}, fields: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			ctx, l := newLoggerContext()
+
+			closeLog, err := Configure(ctx, tt.format, "")
+			require.NoError(t, err)
+			assert.NoError(t, closeLog())
+
+			assert.Equal(t, tt.formatter, l.Formatter)
+			assert.Equal(t, tt.fields, findFieldsHook(l) != nil)
+		})
+	}
+}
+
+func TestConfigureInvalidFormat(t *testing.T) {
+	ctx, l := newLoggerContext()
+	file := filepath.Join(t.TempDir(), "github-cli.log")
+
+	_, err := Configure(ctx, "xml", file)
+	assert.EqualError(t, err, `unsupported log format "xml", expected one of text, json, logfmt`)
+
+	assert.IsType(t, &logrus.TextFormatter{}, l.Formatter, "the formatter is left as is")
+	assert.Nil(t, findFieldsHook(l))
+	assert.NoFileExists(t, file)
+}
+
+func TestConfigureNoColor(t *testing.T) {
+	ctx, l := newLoggerContext()
+	l.SetOutput(os.Stderr)
+	t.Setenv("NO_COLOR", "")
+
+	_, err := Configure(ctx, "text", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, &formatter.CliFormatter{DisableColors: true}, l.Formatter)
+}
+
+func TestConfigureFile(t *testing.T) {
+	ctx, l := newLoggerContext()
+	file := filepath.Join(t.TempDir(), "github-cli.log")
+
+	for _, message := range []string{"first", "second"} {
+		closeLog, err := Configure(ctx, "json", file)
+		require.NoError(t, err)
+
+		l.Info(message)
+
+		require.NoError(t, closeLog())
+		assert.Equal(t, os.Stderr, l.Out, "the logger writes to stderr once the file is closed")
+	}
+
+	b, err := ioutil.ReadFile(file)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	require.Len(t, lines, 2, "the logs are appended")
+	assert.Contains(t, lines[0], `"msg":"first"`)
+	assert.Contains(t, lines[1], `"msg":"second"`)
+
+	info, err := os.Stat(file)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestAddFields(t *testing.T) {
+	ctx, l := newLoggerContext()
+	var out bytes.Buffer
+
+	_, err := Configure(ctx, "json", "")
+	require.NoError(t, err)
+	l.SetOutput(&out)
+
+	AddFields(ctx, logrus.Fields{FieldCommand: "repo create", FieldOrg: "hellofresh"})
+	AddField(ctx, FieldRepo, "svc")
+	l.WithField(FieldRepo, "other").Info("created")
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &entry))
+	assert.Equal(t, "repo create", entry[FieldCommand])
+	assert.Equal(t, "hellofresh", entry[FieldOrg])
+	assert.Equal(t, "other", entry[FieldRepo], "fields of the entry take precedence")
+}
+
+func TestAddFieldsText(t *testing.T) {
+	ctx, l := newLoggerContext()
+
+	_, err := Configure(ctx, "text", "")
+	require.NoError(t, err)
+
+	AddField(ctx, FieldOrg, "hellofresh")
+	assert.Nil(t, findFieldsHook(l), "text logs get no fields")
+}
@@ -0,0 +1,24 @@
+package repo
+
+import "context"
+
+type repoKeyType int
+
+const repoKey repoKeyType = iota
+
+// NewContext returns a context with the github repository client, commands use it instead of
+// the github client of the context, e.g. to run against mocked services
+func NewContext(ctx context.Context, r *GithubRepo) context.Context {
+	return context.WithValue(ctx, repoKey, r)
+}
+
+// WithContext returns the github repository client of the context, nil when there is none
+func WithContext(ctx context.Context) *GithubRepo {
+	if ctx == nil {
+		return nil
+	}
+
+	r, _ := ctx.Value(repoKey).(*GithubRepo)
+
+	return r
+}
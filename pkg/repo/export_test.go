@@ -0,0 +1,11 @@
+package repo
+
+import "time"
+
+// SetTransferWait shortens the wait for transfers in tests and returns a function restoring it
+func SetTransferWait(wait time.Duration) func() {
+	previous := transferWait
+	transferWait = wait
+
+	return func() { transferWait = previous }
+}
@@ -3,8 +3,10 @@ package repo
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/google/go-github/v33/github"
 	multierror "github.com/hashicorp/go-multierror"
@@ -15,7 +17,13 @@ import (
 type (
 	// GithubRepo contains all the hellofresh repository creation Opts for github
 	GithubRepo struct {
-		GithubClient *github.Client
+		Repositories  RepositoriesService
+		Teams         TeamsService
+		Organizations OrganizationsService
+		Issues        IssuesService
+		Users         UsersService
+		// Client sends the requests go-github does not support yet
+		Client Requester
 	}
 
 	// GithubRepoOpts represents the repo creation options
@@ -27,24 +35,47 @@ type (
 		BranchProtections config.BranchProtections
 	}
 
+	// CollaboratorStatus tells how a collaborator got access to a repository
+	CollaboratorStatus string
+
+	// CollaboratorResult is the outcome of adding a collaborator to a repository
+	CollaboratorResult struct {
+		Username   string
+		Permission string
+		Status     CollaboratorStatus
+	}
+
 	// LabelsOpts represents label options
 	LabelsOpts struct {
+		// RemoveDefaultLabels deletes GitHub's default labels that are not configured
 		RemoveDefaultLabels bool
-		Labels              []*config.Label
+		// RemoveUnknown deletes all the labels that are not configured
+		RemoveUnknown bool
+		Labels        []*config.Label
 	}
 )
 
+const (
+	// CollaboratorAdded is used when the user got access right away, e.g. members of the organization
+	CollaboratorAdded CollaboratorStatus = "added"
+	// CollaboratorInvited is used when the user has to accept an invitation
+	CollaboratorInvited CollaboratorStatus = "invited"
+	// CollaboratorPending is used when the user already had a pending invitation, which was updated
+	CollaboratorPending CollaboratorStatus = "pending"
+)
+
+const (
+	defaultBranchAttempts = 5
+	defaultBranchWait     = 500 * time.Millisecond
+)
+
 var (
+	// ErrBranchNotFound is used when a branch does not exist
+	ErrBranchNotFound = errors.New("github branch does not exist")
 	// ErrRepositoryAlreadyExists is used when the repository already exists
 	ErrRepositoryAlreadyExists = errors.New("github repository already exists")
 	// ErrRepositoryLimitExceeded is used when the repository limit is exceeded
 	ErrRepositoryLimitExceeded = errors.New("limit for private repos on this account is exceeded")
-	// ErrLabelNotFound is used when a label is not found
-	ErrLabelNotFound = errors.New("github label does not exist")
-	// ErrLabeAlreadyExists is used when a label is not found
-	ErrLabeAlreadyExists = errors.New("github label already exists")
-	// ErrWebhookAlreadyExist is used when a webhook already exists
-	ErrWebhookAlreadyExist = errors.New("github webhook already exists")
 	// ErrOrganizationNotFound is used when a webhook already exists
 	ErrOrganizationNotFound = errors.New("you must specify an organization to use this functionality")
 )
@@ -52,13 +83,18 @@ var (
 // NewGithub creates a new instance of Client
 func NewGithub(githubClient *github.Client) *GithubRepo {
 	return &GithubRepo{
-		GithubClient: githubClient,
+		Repositories:  githubClient.Repositories,
+		Teams:         githubClient.Teams,
+		Organizations: githubClient.Organizations,
+		Issues:        githubClient.Issues,
+		Users:         githubClient.Users,
+		Client:        githubClient,
 	}
 }
 
 // CreateRepo creates a github repository
 func (c *GithubRepo) CreateRepo(ctx context.Context, org string, repoOpts *github.Repository) (*github.Repository, error) {
-	ghRepo, _, err := c.GithubClient.Repositories.Create(ctx, org, repoOpts)
+	ghRepo, _, err := c.Repositories.Create(ctx, org, repoOpts)
 	if githubError, ok := err.(*github.ErrorResponse); ok {
 		if strings.Contains(githubError.Message, "Visibility can't be private") {
 			err = ErrRepositoryLimitExceeded
@@ -70,6 +106,38 @@ func (c *GithubRepo) CreateRepo(ctx context.Context, org string, repoOpts *githu
 	return ghRepo, err
 }
 
+// DefaultBranch waits for the default branch of the repository to exist and returns its name. Github
+// creates the first commit of auto initialized repositories in the background.
+func (c *GithubRepo) DefaultBranch(ctx context.Context, repo string, org string) (string, error) {
+	ghRepo, _, err := c.Repositories.Get(ctx, org, repo)
+	if err != nil {
+		return "", err
+	}
+
+	branch := ghRepo.GetDefaultBranch()
+	wait := defaultBranchWait
+	for attempt := 1; ; attempt++ {
+		_, resp, err := c.Repositories.GetBranch(ctx, org, repo, branch)
+		if err == nil {
+			return branch, nil
+		}
+
+		if resp == nil || resp.StatusCode != http.StatusNotFound {
+			return "", err
+		}
+		if attempt == defaultBranchAttempts {
+			return "", fmt.Errorf("%w: %s has no commits yet", ErrBranchNotFound, branch)
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(wait):
+			wait *= 2
+		}
+	}
+}
+
 // AddTeamsToRepo adds an slice of teams and their permissions to a repository
 func (c *GithubRepo) AddTeamsToRepo(ctx context.Context, repo string, org string, teams []*config.Team) error {
 	var err error
@@ -83,12 +151,12 @@ func (c *GithubRepo) AddTeamsToRepo(ctx context.Context, repo string, org string
 			Permission: team.Permission,
 		}
 
-		orgInfo, _, ghErr := c.GithubClient.Organizations.Get(ctx, org)
+		orgInfo, _, ghErr := c.Organizations.Get(ctx, org)
 		if ghErr != nil {
-			return err
+			return ghErr
 		}
 
-		if _, ghErr := c.GithubClient.Teams.AddTeamRepoByID(ctx, orgInfo.GetID(), int64(team.ID), "", repo, opt); ghErr != nil {
+		if _, ghErr := c.Teams.AddTeamRepoByID(ctx, orgInfo.GetID(), int64(team.ID), org, repo, opt); ghErr != nil {
 			err = multierror.Append(err, ghErr)
 		}
 	}
@@ -96,55 +164,26 @@ func (c *GithubRepo) AddTeamsToRepo(ctx context.Context, repo string, org string
 	return err
 }
 
-// AddLabelsToRepo adds an slice of labels to the repository. Optionally this can also remove github's
-// default labels
+// AddLabelsToRepo makes the labels of the repository match the configured ones. Optionally this can also
+// remove the labels that are not configured, such as github's default labels
 func (c *GithubRepo) AddLabelsToRepo(ctx context.Context, repo string, org string, opts *LabelsOpts) error {
-	var err error
-	defaultLabels := []string{"bug", "duplicate", "enhancement", "help wanted", "invalid", "question", "wontfix", "good first issue"}
-
-	for _, label := range opts.Labels {
-		githubLabel := &github.Label{
-			Name:  github.String(label.Name),
-			Color: github.String(label.Color),
-		}
-
-		if _, _, ghErr := c.GithubClient.Issues.CreateLabel(ctx, org, repo, githubLabel); ghErr != nil {
-			if internalErr, ok := ghErr.(*github.ErrorResponse); ok {
-				if internalErr.Response.StatusCode == http.StatusUnprocessableEntity {
-					err = multierror.Append(err, ErrLabeAlreadyExists)
-				}
-			}
-		}
-	}
-
-	if opts.RemoveDefaultLabels {
-		for _, label := range defaultLabels {
-			if _, ghErr := c.GithubClient.Issues.DeleteLabel(ctx, org, repo, label); ghErr != nil {
-				if internalErr, ok := ghErr.(*github.ErrorResponse); ok {
-					if internalErr.Response.StatusCode == http.StatusNotFound {
-						err = multierror.Append(err, ErrLabelNotFound)
-					}
-				}
-			}
-		}
-	}
-
+	_, err := c.SyncLabels(ctx, repo, org, opts)
 	return err
 }
 
-// AddWebhooksToRepo adds an slice of webhooks to the repository
+// AddWebhooksToRepo adds an slice of webhooks to the repository. Webhooks that already exist
+// with the same url are updated instead.
 func (c *GithubRepo) AddWebhooksToRepo(ctx context.Context, repo string, org string, webhooks []*config.Webhook) error {
 	var err error
 
+	existing, err := c.ListWebhooks(ctx, repo, org)
+	if err != nil {
+		return err
+	}
+
 	for _, webhook := range webhooks {
-		hook := &github.Hook{
-			Config: webhook.Config,
-		}
-		_, _, err = c.GithubClient.Repositories.CreateHook(ctx, org, repo, hook)
-		if ghErr, ok := err.(*github.ErrorResponse); ok {
-			if ghErr.Response.StatusCode == http.StatusUnprocessableEntity {
-				err = multierror.Append(err, ErrWebhookAlreadyExist)
-			}
+		if _, _, ghErr := c.UpsertWebhook(ctx, repo, org, webhook, existing); ghErr != nil {
+			err = multierror.Append(err, ghErr)
 		}
 	}
 
@@ -161,7 +200,7 @@ func (c *GithubRepo) AddBranchProtections(ctx context.Context, repo string, org
 				Contexts: contexts,
 			},
 		}
-		if _, _, ghErr := c.GithubClient.Repositories.UpdateBranchProtection(ctx, org, repo, branch, pr); ghErr != nil {
+		if _, _, ghErr := c.Repositories.UpdateBranchProtection(ctx, org, repo, branch, pr); ghErr != nil {
 			err = multierror.Append(err, ghErr)
 		}
 	}
@@ -169,19 +208,156 @@ func (c *GithubRepo) AddBranchProtections(ctx context.Context, repo string, org
 	return err
 }
 
-// AddCollaborators adds an slice of collaborators and their permissions to the repository
-func (c *GithubRepo) AddCollaborators(ctx context.Context, repo string, org string, collaborators []*config.Collaborator) error {
-	var err error
+// AddCollaborators grants the collaborators access to the repository. Users that are not members of the
+// organization are invited, and pending invitations are updated instead of sent again.
+func (c *GithubRepo) AddCollaborators(ctx context.Context, repo string, org string, collaborators []*config.Collaborator) ([]*CollaboratorResult, error) {
+	var (
+		err     error
+		results []*CollaboratorResult
+	)
+
+	invitations, err := c.ListInvitations(ctx, repo, org)
+	if err != nil {
+		return nil, err
+	}
 
 	for _, collaborator := range collaborators {
+		result := &CollaboratorResult{Username: collaborator.Username, Permission: collaborator.Permission}
+
+		if invitation := findInvitation(invitations, collaborator.Username); invitation != nil {
+			if _, _, ghErr := c.Repositories.UpdateInvitation(ctx, org, repo, invitation.GetID(), invitationPermission(collaborator.Permission)); ghErr != nil {
+				err = multierror.Append(err, ghErr)
+				continue
+			}
+
+			result.Status = CollaboratorPending
+			results = append(results, result)
+			continue
+		}
+
 		opt := &github.RepositoryAddCollaboratorOptions{
 			Permission: collaborator.Permission,
 		}
 
-		if _, _, ghErr := c.GithubClient.Repositories.AddCollaborator(ctx, org, repo, collaborator.Username, opt); ghErr != nil {
+		invitation, _, ghErr := c.Repositories.AddCollaborator(ctx, org, repo, collaborator.Username, opt)
+		if ghErr != nil {
 			err = multierror.Append(err, ghErr)
+			continue
 		}
+
+		// github answers with an invitation for outside users, members get access right away
+		result.Status = CollaboratorAdded
+		if invitation != nil && invitation.GetID() != 0 {
+			result.Status = CollaboratorInvited
+		}
+		results = append(results, result)
 	}
 
-	return err
+	return results, err
+}
+
+// ListInvitations returns the invitations to the repository that were not accepted yet
+func (c *GithubRepo) ListInvitations(ctx context.Context, repo string, org string) ([]*github.RepositoryInvitation, error) {
+	var allInvitations []*github.RepositoryInvitation
+
+	opt := &github.ListOptions{PerPage: 100}
+	for {
+		invitations, resp, err := c.Repositories.ListInvitations(ctx, org, repo, opt)
+		if err != nil {
+			return allInvitations, err
+		}
+
+		allInvitations = append(allInvitations, invitations...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+
+	return allInvitations, nil
+}
+
+// ListTeams returns all the teams with access to a repository
+func (c *GithubRepo) ListTeams(ctx context.Context, repo string, org string) ([]*github.Team, error) {
+	var allTeams []*github.Team
+
+	opt := &github.ListOptions{PerPage: 100}
+	for {
+		teams, resp, err := c.Repositories.ListTeams(ctx, org, repo, opt)
+		if err != nil {
+			return allTeams, err
+		}
+
+		allTeams = append(allTeams, teams...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+
+	return allTeams, nil
+}
+
+// ListOutsideCollaborators returns all the collaborators of a repository that are not members of the organization
+func (c *GithubRepo) ListOutsideCollaborators(ctx context.Context, repo string, org string) ([]*github.User, error) {
+	var allUsers []*github.User
+
+	opt := &github.ListCollaboratorsOptions{Affiliation: "outside", ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		users, resp, err := c.Repositories.ListCollaborators(ctx, org, repo, opt)
+		if err != nil {
+			return allUsers, err
+		}
+
+		allUsers = append(allUsers, users...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+
+	return allUsers, nil
+}
+
+// ListProtectedBranches returns all the protected branches of a repository
+func (c *GithubRepo) ListProtectedBranches(ctx context.Context, repo string, org string) ([]*github.Branch, error) {
+	var allBranches []*github.Branch
+
+	opt := &github.BranchListOptions{Protected: github.Bool(true), ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		branches, resp, err := c.Repositories.ListBranches(ctx, org, repo, opt)
+		if err != nil {
+			return allBranches, err
+		}
+
+		allBranches = append(allBranches, branches...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+
+	return allBranches, nil
+}
+
+func findInvitation(invitations []*github.RepositoryInvitation, username string) *github.RepositoryInvitation {
+	for _, invitation := range invitations {
+		if strings.EqualFold(invitation.GetInvitee().GetLogin(), username) {
+			return invitation
+		}
+	}
+
+	return nil
+}
+
+// invitationPermission converts a permission to the names used by invitations
+func invitationPermission(permission string) string {
+	switch permission {
+	case "pull":
+		return "read"
+	case "push":
+		return "write"
+	}
+
+	return permission
 }
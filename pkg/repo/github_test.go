@@ -0,0 +1,70 @@
+package repo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-github/v33/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hellofresh/github-cli/pkg/repo/repotest"
+)
+
+// page matches the list options of the given page
+func page(n int) interface{} {
+	return mock.MatchedBy(func(opt interface{}) bool {
+		switch o := opt.(type) {
+		case *github.ListOptions:
+			return o.Page == n
+		case *github.ListCollaboratorsOptions:
+			return o.Affiliation == "outside" && o.Page == n
+		case *github.BranchListOptions:
+			return o.GetProtected() && o.Page == n
+		}
+		return false
+	})
+}
+
+func TestListTeamsFollowsPages(t *testing.T) {
+	mocks := repotest.NewMocks()
+	mocks.Repositories.On("ListTeams", mock.Anything, "hellofresh", "svc", page(0)).
+		Return([]*github.Team{{Slug: github.String("a")}}, &github.Response{NextPage: 2}, nil)
+	mocks.Repositories.On("ListTeams", mock.Anything, "hellofresh", "svc", page(2)).
+		Return([]*github.Team{{Slug: github.String("b")}}, &github.Response{}, nil)
+
+	teams, err := mocks.GithubRepo().ListTeams(context.Background(), "svc", "hellofresh")
+	require.NoError(t, err)
+
+	assert.Equal(t, []*github.Team{{Slug: github.String("a")}, {Slug: github.String("b")}}, teams)
+	mocks.AssertExpectations(t)
+}
+
+func TestListOutsideCollaboratorsFollowsPages(t *testing.T) {
+	mocks := repotest.NewMocks()
+	mocks.Repositories.On("ListCollaborators", mock.Anything, "hellofresh", "svc", page(0)).
+		Return([]*github.User{{Login: github.String("a")}}, &github.Response{NextPage: 2}, nil)
+	mocks.Repositories.On("ListCollaborators", mock.Anything, "hellofresh", "svc", page(2)).
+		Return([]*github.User{{Login: github.String("b")}}, &github.Response{}, nil)
+
+	users, err := mocks.GithubRepo().ListOutsideCollaborators(context.Background(), "svc", "hellofresh")
+	require.NoError(t, err)
+
+	assert.Equal(t, []*github.User{{Login: github.String("a")}, {Login: github.String("b")}}, users)
+	mocks.AssertExpectations(t)
+}
+
+func TestListProtectedBranchesFollowsPages(t *testing.T) {
+	mocks := repotest.NewMocks()
+	mocks.Repositories.On("ListBranches", mock.Anything, "hellofresh", "svc", page(0)).
+		Return([]*github.Branch{{Name: github.String("main")}}, &github.Response{NextPage: 2}, nil)
+	mocks.Repositories.On("ListBranches", mock.Anything, "hellofresh", "svc", page(2)).
+		Return([]*github.Branch{{Name: github.String("release")}}, &github.Response{}, nil)
+
+	branches, err := mocks.GithubRepo().ListProtectedBranches(context.Background(), "svc", "hellofresh")
+	require.NoError(t, err)
+
+	assert.Equal(t, []*github.Branch{{Name: github.String("main")}, {Name: github.String("release")}}, branches)
+	mocks.AssertExpectations(t)
+}
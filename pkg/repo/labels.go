@@ -0,0 +1,257 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v33/github"
+	multierror "github.com/hashicorp/go-multierror"
+
+	"github.com/hellofresh/github-cli/pkg/config"
+)
+
+type (
+	// LabelAction is a change made to a label of a repository
+	LabelAction string
+
+	// LabelChange is the change needed for a label of a repository to match the configuration
+	LabelChange struct {
+		Action LabelAction
+		// Label is the configured label, nil when the label is deleted
+		Label *config.Label
+		// Current is the label of the repository, nil when the label is created
+		Current *github.Label
+	}
+)
+
+const (
+	// LabelCreate creates a configured label missing on the repository
+	LabelCreate LabelAction = "create"
+	// LabelUpdate changes the color or description of a label
+	LabelUpdate LabelAction = "update"
+	// LabelRename renames a label named after one of the aliases, keeping its issues and pull requests
+	LabelRename LabelAction = "rename"
+	// LabelMerge moves the issues and pull requests of a label named after one of the aliases to the
+	// configured label, which already exists, and deletes it
+	LabelMerge LabelAction = "merge"
+	// LabelDelete deletes a label that is not configured
+	LabelDelete LabelAction = "delete"
+)
+
+// defaultLabels are the labels github adds to new repositories
+var defaultLabels = []string{"bug", "duplicate", "enhancement", "help wanted", "invalid", "question", "wontfix", "good first issue"}
+
+// String describes the change, e.g. `~ update "bug": color ee0701 -> d73a4a`
+func (c *LabelChange) String() string {
+	switch c.Action {
+	case LabelCreate:
+		return fmt.Sprintf("+ create %q (%s)", c.Label.Name, c.Label.Color)
+	case LabelMerge:
+		return fmt.Sprintf("> merge %q into %q", c.Current.GetName(), c.Label.Name)
+	case LabelDelete:
+		return fmt.Sprintf("- delete %q", c.Current.GetName())
+	}
+
+	var details []string
+	if c.Action == LabelRename || c.Current.GetName() != c.Label.Name {
+		details = append(details, fmt.Sprintf("name %q -> %q", c.Current.GetName(), c.Label.Name))
+	}
+	if !strings.EqualFold(c.Current.GetColor(), c.Label.Color) {
+		details = append(details, fmt.Sprintf("color %s -> %s", c.Current.GetColor(), c.Label.Color))
+	}
+	if c.Current.GetDescription() != c.Label.Description {
+		details = append(details, fmt.Sprintf("description %q -> %q", c.Current.GetDescription(), c.Label.Description))
+	}
+
+	symbol := "~"
+	if c.Action == LabelRename {
+		symbol = ">"
+	}
+
+	return fmt.Sprintf("%s %s %q: %s", symbol, c.Action, c.Current.GetName(), strings.Join(details, ", "))
+}
+
+// ListLabels returns all the labels of the repository
+func (c *GithubRepo) ListLabels(ctx context.Context, repo string, org string) ([]*github.Label, error) {
+	var allLabels []*github.Label
+
+	opt := &github.ListOptions{PerPage: 100}
+	for {
+		labels, resp, err := c.Issues.ListLabels(ctx, org, repo, opt)
+		if err != nil {
+			return allLabels, err
+		}
+
+		allLabels = append(allLabels, labels...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+
+	return allLabels, nil
+}
+
+// PlanLabels compares the labels of a repository with the configured ones and returns the changes
+// needed for them to match. Labels that are not configured are only deleted when opts.RemoveUnknown is
+// set, or when they are github's default labels and opts.RemoveDefaultLabels is set.
+func PlanLabels(current []*github.Label, opts *LabelsOpts) []*LabelChange {
+	labels := opts.Labels
+	var changes []*LabelChange
+	matched := make(map[*github.Label]bool)
+
+	find := func(name string) *github.Label {
+		for _, label := range current {
+			if !matched[label] && strings.EqualFold(label.GetName(), name) {
+				return label
+			}
+		}
+		return nil
+	}
+
+	// exact names are matched first so an alias never steals a label that is configured on its own
+	for _, label := range labels {
+		if existing := find(label.Name); existing != nil {
+			matched[existing] = true
+			if existing.GetName() != label.Name || !strings.EqualFold(existing.GetColor(), label.Color) || existing.GetDescription() != label.Description {
+				changes = append(changes, &LabelChange{Action: LabelUpdate, Label: label, Current: existing})
+			}
+		}
+	}
+
+	for _, label := range labels {
+		if hasLabel(current, label.Name) {
+			continue
+		}
+
+		change := &LabelChange{Action: LabelCreate, Label: label}
+		for _, alias := range label.Aliases {
+			if existing := find(alias); existing != nil {
+				matched[existing] = true
+				change.Action, change.Current = LabelRename, existing
+				break
+			}
+		}
+		changes = append(changes, change)
+	}
+
+	// an alias next to the label it names is merged so its issues are not left without the label
+	for _, label := range labels {
+		for _, alias := range label.Aliases {
+			if existing := find(alias); existing != nil {
+				matched[existing] = true
+				changes = append(changes, &LabelChange{Action: LabelMerge, Label: label, Current: existing})
+			}
+		}
+	}
+
+	for _, label := range current {
+		if matched[label] {
+			continue
+		}
+
+		if opts.RemoveUnknown || opts.RemoveDefaultLabels && isDefaultLabel(label.GetName()) {
+			changes = append(changes, &LabelChange{Action: LabelDelete, Current: label})
+		}
+	}
+
+	return changes
+}
+
+// ApplyLabelChanges makes the planned changes to the labels of the repository
+func (c *GithubRepo) ApplyLabelChanges(ctx context.Context, repo string, org string, changes []*LabelChange) error {
+	var err error
+
+	for _, change := range changes {
+		var ghErr error
+
+		switch change.Action {
+		case LabelCreate:
+			_, _, ghErr = c.Issues.CreateLabel(ctx, org, repo, toGithubLabel(change.Label))
+		case LabelUpdate, LabelRename:
+			// editing the name keeps the label on its issues and pull requests
+			_, _, ghErr = c.Issues.EditLabel(ctx, org, repo, change.Current.GetName(), toGithubLabel(change.Label))
+		case LabelMerge:
+			ghErr = c.mergeLabel(ctx, repo, org, change.Current.GetName(), change.Label.Name)
+		case LabelDelete:
+			_, ghErr = c.Issues.DeleteLabel(ctx, org, repo, change.Current.GetName())
+		}
+
+		if ghErr != nil {
+			err = multierror.Append(err, fmt.Errorf("could not %s label: %w", change.Action, ghErr))
+		}
+	}
+
+	return err
+}
+
+// SyncLabels makes the labels of the repository match the configured ones and returns the changes made
+func (c *GithubRepo) SyncLabels(ctx context.Context, repo string, org string, opts *LabelsOpts) ([]*LabelChange, error) {
+	current, err := c.ListLabels(ctx, repo, org)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := PlanLabels(current, opts)
+
+	return changes, c.ApplyLabelChanges(ctx, repo, org, changes)
+}
+
+// mergeLabel adds the label into to the issues and pull requests labeled from, then deletes from
+func (c *GithubRepo) mergeLabel(ctx context.Context, repo string, org string, from string, into string) error {
+	opt := &github.IssueListByRepoOptions{
+		State:       "all",
+		Labels:      []string{from},
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	for {
+		issues, resp, err := c.Issues.ListByRepo(ctx, org, repo, opt)
+		if err != nil {
+			return err
+		}
+
+		for _, issue := range issues {
+			if _, _, err := c.Issues.AddLabelsToIssue(ctx, org, repo, issue.GetNumber(), []string{into}); err != nil {
+				return fmt.Errorf("could not label #%d: %w", issue.GetNumber(), err)
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+
+	_, err := c.Issues.DeleteLabel(ctx, org, repo, from)
+
+	return err
+}
+
+func isDefaultLabel(name string) bool {
+	for _, label := range defaultLabels {
+		if strings.EqualFold(label, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func toGithubLabel(label *config.Label) *github.Label {
+	return &github.Label{
+		Name:        github.String(label.Name),
+		Color:       github.String(strings.ToLower(label.Color)),
+		Description: github.String(label.Description),
+	}
+}
+
+func hasLabel(labels []*github.Label, name string) bool {
+	for _, label := range labels {
+		if strings.EqualFold(label.GetName(), name) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,109 @@
+package repo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-github/v33/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hellofresh/github-cli/pkg/config"
+	"github.com/hellofresh/github-cli/pkg/test"
+)
+
+func labels(names ...string) []*github.Label {
+	var labels []*github.Label
+	for _, name := range names {
+		labels = append(labels, &github.Label{Name: github.String(name), Color: github.String("ededed")})
+	}
+
+	return labels
+}
+
+func TestPlanLabels(t *testing.T) {
+	configured := []*config.Label{
+		{Name: "type: bug", Color: "d73a4a", Aliases: []string{"bug"}},
+		{Name: "needs-review", Color: "ededed", Aliases: []string{"needs review"}},
+	}
+
+	tests := []struct {
+		name    string
+		current []*github.Label
+		opts    *LabelsOpts
+		changes []string
+	}{
+		{
+			name:    "creates the missing labels",
+			current: nil,
+			opts:    &LabelsOpts{Labels: configured},
+			changes: []string{`+ create "type: bug" (d73a4a)`, `+ create "needs-review" (ededed)`},
+		},
+		{
+			name:    "renames an alias",
+			current: labels("bug", "needs-review"),
+			opts:    &LabelsOpts{Labels: configured},
+			changes: []string{`> rename "bug": name "bug" -> "type: bug", color ededed -> d73a4a`},
+		},
+		{
+			name:    "merges an alias into the existing label",
+			current: labels("type: bug", "needs-review", "needs review"),
+			opts:    &LabelsOpts{Labels: configured, RemoveUnknown: true},
+			changes: []string{`~ update "type: bug": color ededed -> d73a4a`, `> merge "needs review" into "needs-review"`},
+		},
+		{
+			name:    "only removes the default labels",
+			current: labels("type: bug", "needs-review", "wontfix", "Good First Issue", "team-x-priority"),
+			opts:    &LabelsOpts{Labels: configured, RemoveDefaultLabels: true},
+			changes: []string{`~ update "type: bug": color ededed -> d73a4a`, `- delete "wontfix"`, `- delete "Good First Issue"`},
+		},
+		{
+			name:    "removes the unknown labels",
+			current: labels("needs-review", "team-x-priority"),
+			opts:    &LabelsOpts{Labels: configured[1:], RemoveUnknown: true},
+			changes: []string{`- delete "team-x-priority"`},
+		},
+		{
+			name:    "keeps the unknown labels",
+			current: labels("needs-review", "team-x-priority", "bug"),
+			opts:    &LabelsOpts{Labels: configured[1:]},
+			changes: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var changes []string
+			for _, change := range PlanLabels(tt.current, tt.opts) {
+				changes = append(changes, change.String())
+			}
+
+			assert.Equal(t, tt.changes, changes)
+		})
+	}
+}
+
+func TestSyncLabelsMergesAliases(t *testing.T) {
+	fake := test.NewFakeGithub()
+	fake.AddOrg("hellofresh")
+	r := fake.AddRepo("hellofresh", "svc", false)
+	r.Labels = labels("needs review", "needs-review", "team-x-priority")
+	r.Issues = map[int][]string{1: {"needs review"}, 2: {"needs review", "needs-review"}, 3: {"team-x-priority"}}
+
+	server := fake.Start()
+	defer server.Close()
+
+	opts := &LabelsOpts{
+		Labels:              []*config.Label{{Name: "needs-review", Color: "ededed", Aliases: []string{"needs review"}}},
+		RemoveDefaultLabels: true,
+	}
+	_, err := NewGithub(fake.Client()).SyncLabels(context.Background(), "svc", "hellofresh", opts)
+	require.NoError(t, err)
+
+	var names []string
+	for _, label := range r.Labels {
+		names = append(names, label.GetName())
+	}
+	assert.Equal(t, []string{"needs-review", "team-x-priority"}, names)
+	assert.Equal(t, map[int][]string{1: {"needs-review"}, 2: {"needs-review"}, 3: {"team-x-priority"}}, r.Issues)
+}
@@ -0,0 +1,143 @@
+package repo
+
+import (
+	"context"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v33/github"
+
+	"github.com/hellofresh/github-cli/pkg/log"
+)
+
+// Filter selects repositories, the zero value selects all of them
+type Filter struct {
+	// Visibility is public, private or internal
+	Visibility string
+	// Archived selects archived or active repositories when set
+	Archived *bool
+	Topic    string
+	Language string
+	// Name is a glob pattern, e.g. "api-*"
+	Name         string
+	PushedBefore time.Time
+	PushedAfter  time.Time
+	// Repos are the names of the repositories to keep when set, e.g. the repositories of a team
+	Repos map[string]bool
+}
+
+// FetchAllRepos returns all the repositories of the organization, starting from the given page
+func (c *GithubRepo) FetchAllRepos(ctx context.Context, owner string, reposPerPage int, page int) ([]*github.Repository, error) {
+	var allRepos []*github.Repository
+
+	logger := log.WithContext(ctx)
+	opt := &github.RepositoryListByOrgOptions{
+		ListOptions: github.ListOptions{PerPage: reposPerPage, Page: page},
+	}
+
+	for {
+		logger.Debugf("Fetching repositories page [%d]", opt.Page)
+		repos, resp, err := c.Repositories.ListByOrg(ctx, owner, opt)
+		if err != nil {
+			return allRepos, err
+		}
+
+		allRepos = append(allRepos, repos...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+
+	return allRepos, nil
+}
+
+// TeamRepos returns the names of the repositories a team has access to
+func (c *GithubRepo) TeamRepos(ctx context.Context, org string, slug string) (map[string]bool, error) {
+	names := make(map[string]bool)
+
+	opt := &github.ListOptions{PerPage: 100}
+	for {
+		repos, resp, err := c.Teams.ListTeamReposBySlug(ctx, org, slug, opt)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range repos {
+			names[r.GetName()] = true
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+
+	return names, nil
+}
+
+// Visibility returns the visibility of a repository, which older github versions do not report
+func Visibility(r *github.Repository) string {
+	if r.GetVisibility() != "" {
+		return r.GetVisibility()
+	}
+	if r.GetPrivate() {
+		return "private"
+	}
+
+	return "public"
+}
+
+// Filter returns the repositories matching the filter, keeping their order
+func (f *Filter) Filter(repos []*github.Repository) []*github.Repository {
+	var matched []*github.Repository
+	for _, r := range repos {
+		if f.Match(r) {
+			matched = append(matched, r)
+		}
+	}
+
+	return matched
+}
+
+// Match checks if a repository matches all the criteria of the filter
+func (f *Filter) Match(r *github.Repository) bool {
+	if f.Visibility != "" && !strings.EqualFold(Visibility(r), f.Visibility) {
+		return false
+	}
+	if f.Archived != nil && r.GetArchived() != *f.Archived {
+		return false
+	}
+	if f.Language != "" && !strings.EqualFold(r.GetLanguage(), f.Language) {
+		return false
+	}
+	if f.Topic != "" && !hasTopic(r, f.Topic) {
+		return false
+	}
+	if f.Name != "" {
+		if ok, _ := path.Match(strings.ToLower(f.Name), strings.ToLower(r.GetName())); !ok {
+			return false
+		}
+	}
+	if !f.PushedBefore.IsZero() && !r.GetPushedAt().Before(f.PushedBefore) {
+		return false
+	}
+	if !f.PushedAfter.IsZero() && !r.GetPushedAt().After(f.PushedAfter) {
+		return false
+	}
+	if f.Repos != nil && !f.Repos[r.GetName()] {
+		return false
+	}
+
+	return true
+}
+
+func hasTopic(r *github.Repository, topic string) bool {
+	for _, t := range r.Topics {
+		if strings.EqualFold(t, topic) {
+			return true
+		}
+	}
+
+	return false
+}
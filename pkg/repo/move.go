@@ -0,0 +1,58 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/go-github/v33/github"
+)
+
+const transferAttempts = 6
+
+// transferWait is the first wait for a transfer to complete, it doubles after each attempt
+var transferWait = time.Second
+
+// ErrTransferPending is used when the repository is still not in the new organization after waiting for the transfer
+var ErrTransferPending = errors.New("github repository transfer is still pending")
+
+// RenameRepo renames a repository. Github redirects the old name to the new one until a repository
+// with the old name is created.
+func (c *GithubRepo) RenameRepo(ctx context.Context, org string, repo string, newName string) (*github.Repository, error) {
+	ghRepo, _, err := c.Repositories.Edit(ctx, org, repo, &github.Repository{Name: github.String(newName)})
+	return ghRepo, err
+}
+
+// TransferRepo moves a repository to another organization and waits for it to be available there.
+// The teams of the old organization lose their access. ErrTransferPending is returned when github did not
+// complete the transfer in time.
+func (c *GithubRepo) TransferRepo(ctx context.Context, org string, repo string, newOrg string) (*github.Repository, error) {
+	_, _, err := c.Repositories.Transfer(ctx, org, repo, github.TransferRequest{NewOwner: newOrg})
+	if _, ok := err.(*github.AcceptedError); !ok && err != nil {
+		return nil, err
+	}
+
+	// github moves the repository in the background
+	wait := transferWait
+	for attempt := 1; ; attempt++ {
+		ghRepo, resp, err := c.Repositories.Get(ctx, newOrg, repo)
+		if err == nil && ghRepo.GetOwner().GetLogin() != org {
+			return ghRepo, nil
+		}
+		if err != nil && (resp == nil || resp.StatusCode != http.StatusNotFound) {
+			return nil, err
+		}
+		if attempt == transferAttempts {
+			return nil, fmt.Errorf("%w, check %s/%s later", ErrTransferPending, newOrg, repo)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+			wait *= 2
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package repo_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v33/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hellofresh/github-cli/pkg/repo"
+	"github.com/hellofresh/github-cli/pkg/repo/repotest"
+)
+
+func ownedBy(org string) *github.Repository {
+	return &github.Repository{Name: github.String("svc"), Owner: &github.User{Login: github.String(org)}}
+}
+
+func TestTransferRepo(t *testing.T) {
+	t.Cleanup(repo.SetTransferWait(time.Millisecond))
+
+	notFound := &github.ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotFound}}
+	serverError := &github.ErrorResponse{Response: &http.Response{StatusCode: http.StatusInternalServerError}}
+
+	tests := []struct {
+		name  string
+		gets  [][]interface{}
+		owner string
+		err   error
+	}{
+		{
+			name:  "moved at once",
+			gets:  [][]interface{}{{ownedBy("hellofresh-oss"), &github.Response{}, nil}},
+			owner: "hellofresh-oss",
+		},
+		{
+			name: "moved in the background",
+			gets: [][]interface{}{
+				{nil, &github.Response{Response: notFound.Response}, notFound},
+				{ownedBy("hellofresh"), &github.Response{}, nil},
+				{ownedBy("hellofresh-oss"), &github.Response{}, nil},
+			},
+			owner: "hellofresh-oss",
+		},
+		{
+			name: "never moved",
+			gets: [][]interface{}{{ownedBy("hellofresh"), &github.Response{}, nil}},
+			err:  repo.ErrTransferPending,
+		},
+		{
+			name: "never found",
+			gets: [][]interface{}{{nil, &github.Response{Response: notFound.Response}, notFound}},
+			err:  repo.ErrTransferPending,
+		},
+		{
+			name: "failing",
+			gets: [][]interface{}{{nil, &github.Response{Response: serverError.Response}, serverError}},
+			err:  serverError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocks := repotest.NewMocks()
+			mocks.Repositories.On("Transfer", mock.Anything, "hellofresh", "svc", github.TransferRequest{NewOwner: "hellofresh-oss"}).
+				Return(nil, nil, &github.AcceptedError{})
+			for i, get := range tt.gets {
+				call := mocks.Repositories.On("Get", mock.Anything, "hellofresh-oss", "svc").Return(get...)
+				if i < len(tt.gets)-1 {
+					call.Once()
+				}
+			}
+
+			transferred, err := mocks.GithubRepo().TransferRepo(context.Background(), "hellofresh", "svc", "hellofresh-oss")
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), "unexpected error %v", err)
+				assert.Nil(t, transferred)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.owner, transferred.GetOwner().GetLogin())
+			mocks.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransferRepoRefused(t *testing.T) {
+	mocks := repotest.NewMocks()
+	refused := errors.New("not allowed")
+	mocks.Repositories.On("Transfer", mock.Anything, "hellofresh", "svc", github.TransferRequest{NewOwner: "hellofresh-oss"}).
+		Return(nil, nil, refused)
+
+	_, err := mocks.GithubRepo().TransferRepo(context.Background(), "hellofresh", "svc", "hellofresh-oss")
+	assert.Equal(t, refused, err)
+	mocks.AssertExpectations(t)
+}
@@ -0,0 +1,426 @@
+// Package repotest provides mocks of the github services used by repo.GithubRepo, so commands can
+// run without github:
+//
+//	mocks := repotest.NewMocks()
+//	mocks.Repositories.On("Get", mock.Anything, "hellofresh", "my-repo").Return(&github.Repository{}, nil, nil)
+//	ctx = repo.NewContext(ctx, mocks.GithubRepo())
+//	err := cmd.RunDeleteRepo(ctx, "my-repo", &cmd.DeleteRepoOpts{})
+//	mocks.AssertExpectations(t)
+package repotest
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/go-github/v33/github"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/hellofresh/github-cli/pkg/repo"
+)
+
+type (
+	// RepositoriesService is a repo.RepositoriesService that uses github.com/stretchr/testify/mock
+	RepositoriesService struct {
+		mock.Mock
+	}
+
+	// TeamsService is a repo.TeamsService that uses github.com/stretchr/testify/mock
+	TeamsService struct {
+		mock.Mock
+	}
+
+	// OrganizationsService is a repo.OrganizationsService that uses github.com/stretchr/testify/mock
+	OrganizationsService struct {
+		mock.Mock
+	}
+
+	// IssuesService is a repo.IssuesService that uses github.com/stretchr/testify/mock
+	IssuesService struct {
+		mock.Mock
+	}
+
+	// UsersService is a repo.UsersService that uses github.com/stretchr/testify/mock
+	UsersService struct {
+		mock.Mock
+	}
+
+	// Requester is a repo.Requester that uses github.com/stretchr/testify/mock
+	Requester struct {
+		mock.Mock
+	}
+
+	// Mocks are the mocked services of a repo.GithubRepo
+	Mocks struct {
+		Repositories  *RepositoriesService
+		Teams         *TeamsService
+		Organizations *OrganizationsService
+		Issues        *IssuesService
+		Users         *UsersService
+		Client        *Requester
+	}
+)
+
+var (
+	_ repo.RepositoriesService  = (*RepositoriesService)(nil)
+	_ repo.TeamsService         = (*TeamsService)(nil)
+	_ repo.OrganizationsService = (*OrganizationsService)(nil)
+	_ repo.IssuesService        = (*IssuesService)(nil)
+	_ repo.UsersService         = (*UsersService)(nil)
+	_ repo.Requester            = (*Requester)(nil)
+)
+
+// NewMocks creates mocks without expectations
+func NewMocks() *Mocks {
+	return &Mocks{
+		Repositories:  &RepositoriesService{},
+		Teams:         &TeamsService{},
+		Organizations: &OrganizationsService{},
+		Issues:        &IssuesService{},
+		Users:         &UsersService{},
+		Client:        &Requester{},
+	}
+}
+
+// GithubRepo returns a repo.GithubRepo using the mocks
+func (m *Mocks) GithubRepo() *repo.GithubRepo {
+	return &repo.GithubRepo{
+		Repositories:  m.Repositories,
+		Teams:         m.Teams,
+		Organizations: m.Organizations,
+		Issues:        m.Issues,
+		Users:         m.Users,
+		Client:        m.Client,
+	}
+}
+
+// AssertExpectations asserts the expectations of all the mocks
+func (m *Mocks) AssertExpectations(t mock.TestingT) bool {
+	return m.Repositories.AssertExpectations(t) &&
+		m.Teams.AssertExpectations(t) &&
+		m.Organizations.AssertExpectations(t) &&
+		m.Issues.AssertExpectations(t) &&
+		m.Users.AssertExpectations(t) &&
+		m.Client.AssertExpectations(t)
+}
+
+// Create implements repo.RepositoriesService
+func (m *RepositoriesService) Create(ctx context.Context, org string, repo *github.Repository) (*github.Repository, *github.Response, error) {
+	args := m.Called(ctx, org, repo)
+	r0, _ := args.Get(0).(*github.Repository)
+	r1, _ := args.Get(1).(*github.Response)
+	return r0, r1, args.Error(2)
+}
+
+// Get implements repo.RepositoriesService
+func (m *RepositoriesService) Get(ctx context.Context, owner, repo string) (*github.Repository, *github.Response, error) {
+	args := m.Called(ctx, owner, repo)
+	r0, _ := args.Get(0).(*github.Repository)
+	r1, _ := args.Get(1).(*github.Response)
+	return r0, r1, args.Error(2)
+}
+
+// Edit implements repo.RepositoriesService
+func (m *RepositoriesService) Edit(ctx context.Context, owner, repo string, repository *github.Repository) (*github.Repository, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, repository)
+	r0, _ := args.Get(0).(*github.Repository)
+	r1, _ := args.Get(1).(*github.Response)
+	return r0, r1, args.Error(2)
+}
+
+// Delete implements repo.RepositoriesService
+func (m *RepositoriesService) Delete(ctx context.Context, owner, repo string) (*github.Response, error) {
+	args := m.Called(ctx, owner, repo)
+	r0, _ := args.Get(0).(*github.Response)
+	return r0, args.Error(1)
+}
+
+// GetBranch implements repo.RepositoriesService
+func (m *RepositoriesService) GetBranch(ctx context.Context, owner, repo, branch string) (*github.Branch, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, branch)
+	r0, _ := args.Get(0).(*github.Branch)
+	r1, _ := args.Get(1).(*github.Response)
+	return r0, r1, args.Error(2)
+}
+
+// ListBranches implements repo.RepositoriesService
+func (m *RepositoriesService) ListBranches(ctx context.Context, owner string, repo string, opts *github.BranchListOptions) ([]*github.Branch, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, opts)
+	r0, _ := args.Get(0).([]*github.Branch)
+	r1, _ := args.Get(1).(*github.Response)
+	return r0, r1, args.Error(2)
+}
+
+// GetBranchProtection implements repo.RepositoriesService
+func (m *RepositoriesService) GetBranchProtection(ctx context.Context, owner, repo, branch string) (*github.Protection, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, branch)
+	r0, _ := args.Get(0).(*github.Protection)
+	r1, _ := args.Get(1).(*github.Response)
+	return r0, r1, args.Error(2)
+}
+
+// UpdateBranchProtection implements repo.RepositoriesService
+func (m *RepositoriesService) UpdateBranchProtection(ctx context.Context, owner, repo, branch string, preq *github.ProtectionRequest) (*github.Protection, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, branch, preq)
+	r0, _ := args.Get(0).(*github.Protection)
+	r1, _ := args.Get(1).(*github.Response)
+	return r0, r1, args.Error(2)
+}
+
+// ListTeams implements repo.RepositoriesService
+func (m *RepositoriesService) ListTeams(ctx context.Context, owner string, repo string, opts *github.ListOptions) ([]*github.Team, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, opts)
+	r0, _ := args.Get(0).([]*github.Team)
+	r1, _ := args.Get(1).(*github.Response)
+	return r0, r1, args.Error(2)
+}
+
+// ListCollaborators implements repo.RepositoriesService
+func (m *RepositoriesService) ListCollaborators(ctx context.Context, owner, repo string, opts *github.ListCollaboratorsOptions) ([]*github.User, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, opts)
+	r0, _ := args.Get(0).([]*github.User)
+	r1, _ := args.Get(1).(*github.Response)
+	return r0, r1, args.Error(2)
+}
+
+// AddCollaborator implements repo.RepositoriesService
+func (m *RepositoriesService) AddCollaborator(ctx context.Context, owner, repo, user string, opts *github.RepositoryAddCollaboratorOptions) (*github.CollaboratorInvitation, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, user, opts)
+	r0, _ := args.Get(0).(*github.CollaboratorInvitation)
+	r1, _ := args.Get(1).(*github.Response)
+	return r0, r1, args.Error(2)
+}
+
+// RemoveCollaborator implements repo.RepositoriesService
+func (m *RepositoriesService) RemoveCollaborator(ctx context.Context, owner, repo, user string) (*github.Response, error) {
+	args := m.Called(ctx, owner, repo, user)
+	r0, _ := args.Get(0).(*github.Response)
+	return r0, args.Error(1)
+}
+
+// ListInvitations implements repo.RepositoriesService
+func (m *RepositoriesService) ListInvitations(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryInvitation, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, opts)
+	r0, _ := args.Get(0).([]*github.RepositoryInvitation)
+	r1, _ := args.Get(1).(*github.Response)
+	return r0, r1, args.Error(2)
+}
+
+// UpdateInvitation implements repo.RepositoriesService
+func (m *RepositoriesService) UpdateInvitation(ctx context.Context, owner, repo string, invitationID int64, permissions string) (*github.RepositoryInvitation, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, invitationID, permissions)
+	r0, _ := args.Get(0).(*github.RepositoryInvitation)
+	r1, _ := args.Get(1).(*github.Response)
+	return r0, r1, args.Error(2)
+}
+
+// ListByOrg implements repo.RepositoriesService
+func (m *RepositoriesService) ListByOrg(ctx context.Context, org string, opts *github.RepositoryListByOrgOptions) ([]*github.Repository, *github.Response, error) {
+	args := m.Called(ctx, org, opts)
+	r0, _ := args.Get(0).([]*github.Repository)
+	r1, _ := args.Get(1).(*github.Response)
+	return r0, r1, args.Error(2)
+}
+
+// ListHooks implements repo.RepositoriesService
+func (m *RepositoriesService) ListHooks(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.Hook, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, opts)
+	r0, _ := args.Get(0).([]*github.Hook)
+	r1, _ := args.Get(1).(*github.Response)
+	return r0, r1, args.Error(2)
+}
+
+// CreateHook implements repo.RepositoriesService
+func (m *RepositoriesService) CreateHook(ctx context.Context, owner, repo string, hook *github.Hook) (*github.Hook, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, hook)
+	r0, _ := args.Get(0).(*github.Hook)
+	r1, _ := args.Get(1).(*github.Response)
+	return r0, r1, args.Error(2)
+}
+
+// EditHook implements repo.RepositoriesService
+func (m *RepositoriesService) EditHook(ctx context.Context, owner, repo string, id int64, hook *github.Hook) (*github.Hook, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, id, hook)
+	r0, _ := args.Get(0).(*github.Hook)
+	r1, _ := args.Get(1).(*github.Response)
+	return r0, r1, args.Error(2)
+}
+
+// DeleteHook implements repo.RepositoriesService
+func (m *RepositoriesService) DeleteHook(ctx context.Context, owner, repo string, id int64) (*github.Response, error) {
+	args := m.Called(ctx, owner, repo, id)
+	r0, _ := args.Get(0).(*github.Response)
+	return r0, args.Error(1)
+}
+
+// PingHook implements repo.RepositoriesService
+func (m *RepositoriesService) PingHook(ctx context.Context, owner, repo string, id int64) (*github.Response, error) {
+	args := m.Called(ctx, owner, repo, id)
+	r0, _ := args.Get(0).(*github.Response)
+	return r0, args.Error(1)
+}
+
+// ReplaceAllTopics implements repo.RepositoriesService
+func (m *RepositoriesService) ReplaceAllTopics(ctx context.Context, owner, repo string, topics []string) ([]string, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, topics)
+	r0, _ := args.Get(0).([]string)
+	r1, _ := args.Get(1).(*github.Response)
+	return r0, r1, args.Error(2)
+}
+
+// GetVulnerabilityAlerts implements repo.RepositoriesService
+func (m *RepositoriesService) GetVulnerabilityAlerts(ctx context.Context, owner, repository string) (bool, *github.Response, error) {
+	args := m.Called(ctx, owner, repository)
+	r1, _ := args.Get(1).(*github.Response)
+	return args.Bool(0), r1, args.Error(2)
+}
+
+// EnableVulnerabilityAlerts implements repo.RepositoriesService
+func (m *RepositoriesService) EnableVulnerabilityAlerts(ctx context.Context, owner, repository string) (*github.Response, error) {
+	args := m.Called(ctx, owner, repository)
+	r0, _ := args.Get(0).(*github.Response)
+	return r0, args.Error(1)
+}
+
+// DisableVulnerabilityAlerts implements repo.RepositoriesService
+func (m *RepositoriesService) DisableVulnerabilityAlerts(ctx context.Context, owner, repository string) (*github.Response, error) {
+	args := m.Called(ctx, owner, repository)
+	r0, _ := args.Get(0).(*github.Response)
+	return r0, args.Error(1)
+}
+
+// EnableAutomatedSecurityFixes implements repo.RepositoriesService
+func (m *RepositoriesService) EnableAutomatedSecurityFixes(ctx context.Context, owner, repository string) (*github.Response, error) {
+	args := m.Called(ctx, owner, repository)
+	r0, _ := args.Get(0).(*github.Response)
+	return r0, args.Error(1)
+}
+
+// DisableAutomatedSecurityFixes implements repo.RepositoriesService
+func (m *RepositoriesService) DisableAutomatedSecurityFixes(ctx context.Context, owner, repository string) (*github.Response, error) {
+	args := m.Called(ctx, owner, repository)
+	r0, _ := args.Get(0).(*github.Response)
+	return r0, args.Error(1)
+}
+
+// EnablePages implements repo.RepositoriesService
+func (m *RepositoriesService) EnablePages(ctx context.Context, owner, repo string, pages *github.Pages) (*github.Pages, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, pages)
+	r0, _ := args.Get(0).(*github.Pages)
+	r1, _ := args.Get(1).(*github.Response)
+	return r0, r1, args.Error(2)
+}
+
+// DisablePages implements repo.RepositoriesService
+func (m *RepositoriesService) DisablePages(ctx context.Context, owner, repo string) (*github.Response, error) {
+	args := m.Called(ctx, owner, repo)
+	r0, _ := args.Get(0).(*github.Response)
+	return r0, args.Error(1)
+}
+
+// Transfer implements repo.RepositoriesService
+func (m *RepositoriesService) Transfer(ctx context.Context, owner, repo string, transfer github.TransferRequest) (*github.Repository, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, transfer)
+	r0, _ := args.Get(0).(*github.Repository)
+	r1, _ := args.Get(1).(*github.Response)
+	return r0, r1, args.Error(2)
+}
+
+// ListTeams implements repo.TeamsService
+func (m *TeamsService) ListTeams(ctx context.Context, org string, opts *github.ListOptions) ([]*github.Team, *github.Response, error) {
+	args := m.Called(ctx, org, opts)
+	r0, _ := args.Get(0).([]*github.Team)
+	r1, _ := args.Get(1).(*github.Response)
+	return r0, r1, args.Error(2)
+}
+
+// ListTeamReposBySlug implements repo.TeamsService
+func (m *TeamsService) ListTeamReposBySlug(ctx context.Context, org, slug string, opts *github.ListOptions) ([]*github.Repository, *github.Response, error) {
+	args := m.Called(ctx, org, slug, opts)
+	r0, _ := args.Get(0).([]*github.Repository)
+	r1, _ := args.Get(1).(*github.Response)
+	return r0, r1, args.Error(2)
+}
+
+// AddTeamRepoByID implements repo.TeamsService
+func (m *TeamsService) AddTeamRepoByID(ctx context.Context, orgID, teamID int64, owner, repo string, opts *github.TeamAddTeamRepoOptions) (*github.Response, error) {
+	args := m.Called(ctx, orgID, teamID, owner, repo, opts)
+	r0, _ := args.Get(0).(*github.Response)
+	return r0, args.Error(1)
+}
+
+// Get implements repo.OrganizationsService
+func (m *OrganizationsService) Get(ctx context.Context, org string) (*github.Organization, *github.Response, error) {
+	args := m.Called(ctx, org)
+	r0, _ := args.Get(0).(*github.Organization)
+	r1, _ := args.Get(1).(*github.Response)
+	return r0, r1, args.Error(2)
+}
+
+// ListLabels implements repo.IssuesService
+func (m *IssuesService) ListLabels(ctx context.Context, owner string, repo string, opts *github.ListOptions) ([]*github.Label, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, opts)
+	r0, _ := args.Get(0).([]*github.Label)
+	r1, _ := args.Get(1).(*github.Response)
+	return r0, r1, args.Error(2)
+}
+
+// CreateLabel implements repo.IssuesService
+func (m *IssuesService) CreateLabel(ctx context.Context, owner string, repo string, label *github.Label) (*github.Label, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, label)
+	r0, _ := args.Get(0).(*github.Label)
+	r1, _ := args.Get(1).(*github.Response)
+	return r0, r1, args.Error(2)
+}
+
+// EditLabel implements repo.IssuesService
+func (m *IssuesService) EditLabel(ctx context.Context, owner string, repo string, name string, label *github.Label) (*github.Label, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, name, label)
+	r0, _ := args.Get(0).(*github.Label)
+	r1, _ := args.Get(1).(*github.Response)
+	return r0, r1, args.Error(2)
+}
+
+// DeleteLabel implements repo.IssuesService
+func (m *IssuesService) DeleteLabel(ctx context.Context, owner string, repo string, name string) (*github.Response, error) {
+	args := m.Called(ctx, owner, repo, name)
+	r0, _ := args.Get(0).(*github.Response)
+	return r0, args.Error(1)
+}
+
+// ListByRepo implements repo.IssuesService
+func (m *IssuesService) ListByRepo(ctx context.Context, owner string, repo string, opts *github.IssueListByRepoOptions) ([]*github.Issue, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, opts)
+	r0, _ := args.Get(0).([]*github.Issue)
+	r1, _ := args.Get(1).(*github.Response)
+	return r0, r1, args.Error(2)
+}
+
+// AddLabelsToIssue implements repo.IssuesService
+func (m *IssuesService) AddLabelsToIssue(ctx context.Context, owner string, repo string, number int, labels []string) ([]*github.Label, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, number, labels)
+	r0, _ := args.Get(0).([]*github.Label)
+	r1, _ := args.Get(1).(*github.Response)
+	return r0, r1, args.Error(2)
+}
+
+// Get implements repo.UsersService
+func (m *UsersService) Get(ctx context.Context, user string) (*github.User, *github.Response, error) {
+	args := m.Called(ctx, user)
+	r0, _ := args.Get(0).(*github.User)
+	r1, _ := args.Get(1).(*github.Response)
+	return r0, r1, args.Error(2)
+}
+
+// NewRequest implements repo.Requester
+func (m *Requester) NewRequest(method, urlStr string, body interface{}) (*http.Request, error) {
+	args := m.Called(method, urlStr, body)
+	r0, _ := args.Get(0).(*http.Request)
+	return r0, args.Error(1)
+}
+
+// Do implements repo.Requester
+func (m *Requester) Do(ctx context.Context, req *http.Request, v interface{}) (*github.Response, error) {
+	args := m.Called(ctx, req, v)
+	r0, _ := args.Get(0).(*github.Response)
+	return r0, args.Error(1)
+}
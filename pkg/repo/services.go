@@ -0,0 +1,85 @@
+package repo
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/go-github/v33/github"
+)
+
+type (
+	// RepositoriesService is the part of the github repositories API used by github-cli, implemented by *github.RepositoriesService
+	RepositoriesService interface {
+		Create(ctx context.Context, org string, repo *github.Repository) (*github.Repository, *github.Response, error)
+		Get(ctx context.Context, owner, repo string) (*github.Repository, *github.Response, error)
+		Edit(ctx context.Context, owner, repo string, repository *github.Repository) (*github.Repository, *github.Response, error)
+		Delete(ctx context.Context, owner, repo string) (*github.Response, error)
+		GetBranch(ctx context.Context, owner, repo, branch string) (*github.Branch, *github.Response, error)
+		ListBranches(ctx context.Context, owner string, repo string, opts *github.BranchListOptions) ([]*github.Branch, *github.Response, error)
+		GetBranchProtection(ctx context.Context, owner, repo, branch string) (*github.Protection, *github.Response, error)
+		UpdateBranchProtection(ctx context.Context, owner, repo, branch string, preq *github.ProtectionRequest) (*github.Protection, *github.Response, error)
+		ListTeams(ctx context.Context, owner string, repo string, opts *github.ListOptions) ([]*github.Team, *github.Response, error)
+		ListCollaborators(ctx context.Context, owner, repo string, opts *github.ListCollaboratorsOptions) ([]*github.User, *github.Response, error)
+		AddCollaborator(ctx context.Context, owner, repo, user string, opts *github.RepositoryAddCollaboratorOptions) (*github.CollaboratorInvitation, *github.Response, error)
+		RemoveCollaborator(ctx context.Context, owner, repo, user string) (*github.Response, error)
+		ListInvitations(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryInvitation, *github.Response, error)
+		UpdateInvitation(ctx context.Context, owner, repo string, invitationID int64, permissions string) (*github.RepositoryInvitation, *github.Response, error)
+		ListByOrg(ctx context.Context, org string, opts *github.RepositoryListByOrgOptions) ([]*github.Repository, *github.Response, error)
+		ListHooks(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.Hook, *github.Response, error)
+		CreateHook(ctx context.Context, owner, repo string, hook *github.Hook) (*github.Hook, *github.Response, error)
+		EditHook(ctx context.Context, owner, repo string, id int64, hook *github.Hook) (*github.Hook, *github.Response, error)
+		DeleteHook(ctx context.Context, owner, repo string, id int64) (*github.Response, error)
+		PingHook(ctx context.Context, owner, repo string, id int64) (*github.Response, error)
+		ReplaceAllTopics(ctx context.Context, owner, repo string, topics []string) ([]string, *github.Response, error)
+		GetVulnerabilityAlerts(ctx context.Context, owner, repository string) (bool, *github.Response, error)
+		EnableVulnerabilityAlerts(ctx context.Context, owner, repository string) (*github.Response, error)
+		DisableVulnerabilityAlerts(ctx context.Context, owner, repository string) (*github.Response, error)
+		EnableAutomatedSecurityFixes(ctx context.Context, owner, repository string) (*github.Response, error)
+		DisableAutomatedSecurityFixes(ctx context.Context, owner, repository string) (*github.Response, error)
+		EnablePages(ctx context.Context, owner, repo string, pages *github.Pages) (*github.Pages, *github.Response, error)
+		DisablePages(ctx context.Context, owner, repo string) (*github.Response, error)
+		Transfer(ctx context.Context, owner, repo string, transfer github.TransferRequest) (*github.Repository, *github.Response, error)
+	}
+
+	// TeamsService is the part of the github teams API used by github-cli, implemented by *github.TeamsService
+	TeamsService interface {
+		ListTeams(ctx context.Context, org string, opts *github.ListOptions) ([]*github.Team, *github.Response, error)
+		ListTeamReposBySlug(ctx context.Context, org, slug string, opts *github.ListOptions) ([]*github.Repository, *github.Response, error)
+		AddTeamRepoByID(ctx context.Context, orgID, teamID int64, owner, repo string, opts *github.TeamAddTeamRepoOptions) (*github.Response, error)
+	}
+
+	// OrganizationsService is the part of the github organizations API used by github-cli, implemented by *github.OrganizationsService
+	OrganizationsService interface {
+		Get(ctx context.Context, org string) (*github.Organization, *github.Response, error)
+	}
+
+	// IssuesService is the part of the github issues API used by github-cli, the labels, implemented by *github.IssuesService
+	IssuesService interface {
+		ListLabels(ctx context.Context, owner string, repo string, opts *github.ListOptions) ([]*github.Label, *github.Response, error)
+		CreateLabel(ctx context.Context, owner string, repo string, label *github.Label) (*github.Label, *github.Response, error)
+		EditLabel(ctx context.Context, owner string, repo string, name string, label *github.Label) (*github.Label, *github.Response, error)
+		DeleteLabel(ctx context.Context, owner string, repo string, name string) (*github.Response, error)
+		ListByRepo(ctx context.Context, owner string, repo string, opts *github.IssueListByRepoOptions) ([]*github.Issue, *github.Response, error)
+		AddLabelsToIssue(ctx context.Context, owner string, repo string, number int, labels []string) ([]*github.Label, *github.Response, error)
+	}
+
+	// UsersService is the part of the github users API used by github-cli, implemented by *github.UsersService
+	UsersService interface {
+		Get(ctx context.Context, user string) (*github.User, *github.Response, error)
+	}
+
+	// Requester sends requests to the endpoints go-github does not support yet, implemented by *github.Client
+	Requester interface {
+		NewRequest(method, urlStr string, body interface{}) (*http.Request, error)
+		Do(ctx context.Context, req *http.Request, v interface{}) (*github.Response, error)
+	}
+)
+
+var (
+	_ RepositoriesService  = (*github.RepositoriesService)(nil)
+	_ TeamsService         = (*github.TeamsService)(nil)
+	_ OrganizationsService = (*github.OrganizationsService)(nil)
+	_ IssuesService        = (*github.IssuesService)(nil)
+	_ UsersService         = (*github.UsersService)(nil)
+	_ Requester            = (*github.Client)(nil)
+)
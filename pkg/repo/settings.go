@@ -0,0 +1,260 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/google/go-github/v33/github"
+	multierror "github.com/hashicorp/go-multierror"
+
+	"github.com/hellofresh/github-cli/pkg/config"
+)
+
+// ApplySettings changes the settings of the repository, the settings that are not set are left untouched
+func (c *GithubRepo) ApplySettings(ctx context.Context, repo string, org string, settings *config.RepositorySettings) error {
+	var err error
+
+	edit := &github.Repository{
+		AllowMergeCommit:    settings.AllowMergeCommit,
+		AllowSquashMerge:    settings.AllowSquashMerge,
+		AllowRebaseMerge:    settings.AllowRebaseMerge,
+		DeleteBranchOnMerge: settings.DeleteBranchOnMerge,
+		HasProjects:         settings.HasProjects,
+	}
+	if settings.Visibility != "" {
+		edit.Visibility = github.String(settings.Visibility)
+	}
+	if settings.Homepage != "" {
+		edit.Homepage = github.String(settings.Homepage)
+	}
+	if !reflect.DeepEqual(edit, &github.Repository{}) {
+		if _, _, ghErr := c.Repositories.Edit(ctx, org, repo, edit); ghErr != nil {
+			err = multierror.Append(err, fmt.Errorf("could not edit repository: %w", ghErr))
+		}
+	}
+
+	// fields the github client does not know about yet
+	extra := make(map[string]interface{})
+	if settings.AllowAutoMerge != nil {
+		extra["allow_auto_merge"] = *settings.AllowAutoMerge
+	}
+	if settings.HasDiscussions != nil {
+		extra["has_discussions"] = *settings.HasDiscussions
+	}
+	if settings.SecretScanning != nil {
+		extra["security_and_analysis"] = map[string]interface{}{
+			"secret_scanning": map[string]string{"status": status(*settings.SecretScanning)},
+		}
+	}
+	if len(extra) > 0 {
+		if ghErr := c.editRepo(ctx, repo, org, extra); ghErr != nil {
+			err = multierror.Append(err, fmt.Errorf("could not edit repository: %w", ghErr))
+		}
+	}
+
+	if settings.Topics != nil {
+		if _, _, ghErr := c.Repositories.ReplaceAllTopics(ctx, org, repo, settings.Topics); ghErr != nil {
+			err = multierror.Append(err, fmt.Errorf("could not replace topics: %w", ghErr))
+		}
+	}
+
+	// security updates can only be enabled once vulnerability alerts are
+	if settings.VulnerabilityAlerts != nil {
+		toggle := c.Repositories.DisableVulnerabilityAlerts
+		if *settings.VulnerabilityAlerts {
+			toggle = c.Repositories.EnableVulnerabilityAlerts
+		}
+		if _, ghErr := toggle(ctx, org, repo); ghErr != nil {
+			err = multierror.Append(err, fmt.Errorf("could not %s vulnerability alerts: %w", action(*settings.VulnerabilityAlerts), ghErr))
+		}
+	}
+
+	if settings.DependabotSecurityUpdates != nil {
+		toggle := c.Repositories.DisableAutomatedSecurityFixes
+		if *settings.DependabotSecurityUpdates {
+			toggle = c.Repositories.EnableAutomatedSecurityFixes
+		}
+		if _, ghErr := toggle(ctx, org, repo); ghErr != nil {
+			err = multierror.Append(err, fmt.Errorf("could not %s dependabot security updates: %w", action(*settings.DependabotSecurityUpdates), ghErr))
+		}
+	}
+
+	return err
+}
+
+// Settings returns the current settings of the repository
+func (c *GithubRepo) Settings(ctx context.Context, repo string, org string) (*config.RepositorySettings, error) {
+	// the github client does not know about all the fields yet
+	var raw struct {
+		Visibility          string   `json:"visibility"`
+		Private             bool     `json:"private"`
+		Homepage            string   `json:"homepage"`
+		Topics              []string `json:"topics"`
+		DefaultBranch       string   `json:"default_branch"`
+		AllowMergeCommit    *bool    `json:"allow_merge_commit"`
+		AllowSquashMerge    *bool    `json:"allow_squash_merge"`
+		AllowRebaseMerge    *bool    `json:"allow_rebase_merge"`
+		AllowAutoMerge      *bool    `json:"allow_auto_merge"`
+		DeleteBranchOnMerge *bool    `json:"delete_branch_on_merge"`
+		HasProjects         *bool    `json:"has_projects"`
+		HasDiscussions      *bool    `json:"has_discussions"`
+		SecurityAndAnalysis *struct {
+			SecretScanning            *struct{ Status string } `json:"secret_scanning"`
+			DependabotSecurityUpdates *struct{ Status string } `json:"dependabot_security_updates"`
+		} `json:"security_and_analysis"`
+	}
+
+	req, err := c.Client.NewRequest("GET", fmt.Sprintf("repos/%v/%v", org, repo), nil)
+	if err != nil {
+		return nil, err
+	}
+	// topics are only returned with the preview media type on older github versions
+	req.Header.Set("Accept", "application/vnd.github.mercy-preview+json")
+	if _, err := c.Client.Do(ctx, req, &raw); err != nil {
+		return nil, err
+	}
+
+	settings := &config.RepositorySettings{
+		Visibility:          raw.Visibility,
+		Homepage:            raw.Homepage,
+		Topics:              raw.Topics,
+		DefaultBranch:       raw.DefaultBranch,
+		AllowMergeCommit:    raw.AllowMergeCommit,
+		AllowSquashMerge:    raw.AllowSquashMerge,
+		AllowRebaseMerge:    raw.AllowRebaseMerge,
+		AllowAutoMerge:      raw.AllowAutoMerge,
+		DeleteBranchOnMerge: raw.DeleteBranchOnMerge,
+		HasProjects:         raw.HasProjects,
+		HasDiscussions:      raw.HasDiscussions,
+	}
+	if settings.Visibility == "" {
+		settings.Visibility = "public"
+		if raw.Private {
+			settings.Visibility = "private"
+		}
+	}
+	if sa := raw.SecurityAndAnalysis; sa != nil {
+		if sa.SecretScanning != nil {
+			settings.SecretScanning = github.Bool(sa.SecretScanning.Status == "enabled")
+		}
+		if sa.DependabotSecurityUpdates != nil {
+			settings.DependabotSecurityUpdates = github.Bool(sa.DependabotSecurityUpdates.Status == "enabled")
+		}
+	}
+
+	// reading the alerts requires admin access, they are left unknown without it
+	if enabled, _, err := c.Repositories.GetVulnerabilityAlerts(ctx, org, repo); err == nil {
+		settings.VulnerabilityAlerts = github.Bool(enabled)
+	}
+
+	return settings, nil
+}
+
+// SetDefaultBranch makes the branch the default one. When the branch does not exist it is created from
+// the current default branch, which is kept along with its protections.
+func (c *GithubRepo) SetDefaultBranch(ctx context.Context, repo string, org string, branch string) error {
+	ghRepo, _, err := c.Repositories.Get(ctx, org, repo)
+	if err != nil {
+		return err
+	}
+
+	current := ghRepo.GetDefaultBranch()
+	if current == branch {
+		return nil
+	}
+
+	_, resp, err := c.Repositories.GetBranch(ctx, org, repo, branch)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		err = c.createBranch(ctx, repo, org, branch, current)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, _, err = c.Repositories.Edit(ctx, org, repo, &github.Repository{DefaultBranch: github.String(branch)})
+
+	return err
+}
+
+// createBranch creates a branch pointing at the head of another one
+func (c *GithubRepo) createBranch(ctx context.Context, repo string, org string, branch string, from string) error {
+	head, _, err := c.Repositories.GetBranch(ctx, org, repo, from)
+	if err != nil {
+		return err
+	}
+
+	u := fmt.Sprintf("repos/%v/%v/git/refs", org, repo)
+	req, err := c.Client.NewRequest("POST", u, map[string]string{
+		"ref": "refs/heads/" + branch,
+		"sha": head.GetCommit().GetSHA(),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Client.Do(ctx, req, nil)
+
+	return err
+}
+
+// SetPages enables github pages, served from the root of the default branch, or disables them
+func (c *GithubRepo) SetPages(ctx context.Context, repo string, org string, enabled bool) error {
+	if !enabled {
+		resp, err := c.Repositories.DisablePages(ctx, org, repo)
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			// pages were not enabled
+			return nil
+		}
+
+		return err
+	}
+
+	ghRepo, _, err := c.Repositories.Get(ctx, org, repo)
+	if err != nil {
+		return err
+	}
+
+	_, resp, err := c.Repositories.EnablePages(ctx, org, repo, &github.Pages{
+		Source: &github.PagesSource{
+			Branch: github.String(ghRepo.GetDefaultBranch()),
+			Path:   github.String("/"),
+		},
+	})
+	if resp != nil && resp.StatusCode == http.StatusConflict {
+		// pages are already enabled
+		return nil
+	}
+
+	return err
+}
+
+// editRepo patches the repository with raw fields
+func (c *GithubRepo) editRepo(ctx context.Context, repo string, org string, fields map[string]interface{}) error {
+	u := fmt.Sprintf("repos/%v/%v", org, repo)
+	req, err := c.Client.NewRequest("PATCH", u, fields)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Client.Do(ctx, req, nil)
+
+	return err
+}
+
+func status(enabled bool) string {
+	if enabled {
+		return "enabled"
+	}
+
+	return "disabled"
+}
+
+func action(enabled bool) string {
+	if enabled {
+		return "enable"
+	}
+
+	return "disable"
+}
@@ -0,0 +1,186 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/go-github/v33/github"
+
+	"github.com/hellofresh/github-cli/pkg/config"
+)
+
+type (
+	// HookDelivery is an attempt to deliver an event to a webhook
+	HookDelivery struct {
+		ID          int64     `json:"id"`
+		GUID        string    `json:"guid"`
+		DeliveredAt time.Time `json:"delivered_at"`
+		Redelivery  bool      `json:"redelivery"`
+		Duration    float64   `json:"duration"`
+		Status      string    `json:"status"`
+		StatusCode  int       `json:"status_code"`
+		Event       string    `json:"event"`
+		Action      string    `json:"action"`
+	}
+)
+
+var (
+	// ErrWebhookNotFound is used when a webhook does not exist on the repository
+	ErrWebhookNotFound = errors.New("github webhook does not exist")
+	// ErrHookDeliveryNotFound is used when there is no delivery to redeliver
+	ErrHookDeliveryNotFound = errors.New("github webhook delivery does not exist")
+)
+
+// Failed checks if the delivery was not accepted by the receiver
+func (d *HookDelivery) Failed() bool {
+	return d.StatusCode < 200 || d.StatusCode >= 300
+}
+
+// ListWebhooks returns all the webhooks of the repository
+func (c *GithubRepo) ListWebhooks(ctx context.Context, repo string, org string) ([]*github.Hook, error) {
+	var allHooks []*github.Hook
+
+	opt := &github.ListOptions{PerPage: 100}
+	for {
+		hooks, resp, err := c.Repositories.ListHooks(ctx, org, repo, opt)
+		if err != nil {
+			return allHooks, err
+		}
+
+		allHooks = append(allHooks, hooks...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+
+	return allHooks, nil
+}
+
+// FindWebhook returns the webhook of the repository with the given ID or url
+func (c *GithubRepo) FindWebhook(ctx context.Context, repo string, org string, idOrURL string) (*github.Hook, error) {
+	hooks, err := c.ListWebhooks(ctx, repo, org)
+	if err != nil {
+		return nil, err
+	}
+
+	id, _ := strconv.ParseInt(idOrURL, 10, 64)
+	for _, hook := range hooks {
+		if hook.GetID() == id || hookURL(hook) == idOrURL {
+			return hook, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrWebhookNotFound, idOrURL)
+}
+
+// UpsertWebhook creates the webhook, or updates the webhook of the repository with the same url.
+// It reports whether the webhook was created.
+func (c *GithubRepo) UpsertWebhook(ctx context.Context, repo string, org string, webhook *config.Webhook, existing []*github.Hook) (*github.Hook, bool, error) {
+	hook, err := toHook(ctx, webhook)
+	if err != nil {
+		return nil, false, err
+	}
+
+	for _, current := range existing {
+		if hookURL(current) == webhook.URL() {
+			updated, _, err := c.Repositories.EditHook(ctx, org, repo, current.GetID(), hook)
+			return updated, false, err
+		}
+	}
+
+	created, _, err := c.Repositories.CreateHook(ctx, org, repo, hook)
+
+	return created, true, err
+}
+
+// RemoveWebhook deletes a webhook of the repository
+func (c *GithubRepo) RemoveWebhook(ctx context.Context, repo string, org string, id int64) error {
+	_, err := c.Repositories.DeleteHook(ctx, org, repo, id)
+	return err
+}
+
+// PingWebhook sends a ping event to a webhook of the repository
+func (c *GithubRepo) PingWebhook(ctx context.Context, repo string, org string, id int64) error {
+	_, err := c.Repositories.PingHook(ctx, org, repo, id)
+	return err
+}
+
+// ListWebhookDeliveries returns the most recent deliveries of a webhook, newest first
+func (c *GithubRepo) ListWebhookDeliveries(ctx context.Context, repo string, org string, id int64, limit int) ([]*HookDelivery, error) {
+	u := fmt.Sprintf("repos/%v/%v/hooks/%v/deliveries?per_page=%d", org, repo, id, limit)
+	req, err := c.Client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var deliveries []*HookDelivery
+	if _, err := c.Client.Do(ctx, req, &deliveries); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// RedeliverWebhook delivers again a previous delivery of a webhook
+func (c *GithubRepo) RedeliverWebhook(ctx context.Context, repo string, org string, id int64, deliveryID int64) error {
+	u := fmt.Sprintf("repos/%v/%v/hooks/%v/deliveries/%v/attempts", org, repo, id, deliveryID)
+	req, err := c.Client.NewRequest("POST", u, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Client.Do(ctx, req, nil)
+	if _, ok := err.(*github.AcceptedError); ok {
+		// the redelivery is queued
+		return nil
+	}
+
+	return err
+}
+
+// toHook converts a configured webhook, resolving its secret
+func toHook(ctx context.Context, webhook *config.Webhook) (*github.Hook, error) {
+	hookConfig := make(map[string]interface{}, len(webhook.Config))
+	for key, value := range webhook.Config {
+		hookConfig[key] = value
+	}
+
+	if secret, ok := hookConfig["secret"].(string); ok {
+		resolved, err := config.ResolveSecret(ctx, secret)
+		if err != nil {
+			return nil, fmt.Errorf("could not resolve secret of webhook %s: %w", webhook.URL(), err)
+		}
+		hookConfig["secret"] = resolved
+	}
+
+	// github expects "0" or "1"
+	switch insecure := hookConfig["insecure_ssl"].(type) {
+	case bool:
+		hookConfig["insecure_ssl"] = "0"
+		if insecure {
+			hookConfig["insecure_ssl"] = "1"
+		}
+	case int, int64, float64:
+		hookConfig["insecure_ssl"] = fmt.Sprint(insecure)
+	}
+
+	active := true
+	if webhook.Active != nil {
+		active = *webhook.Active
+	}
+
+	return &github.Hook{
+		Config: hookConfig,
+		Events: webhook.Events,
+		Active: github.Bool(active),
+	}, nil
+}
+
+func hookURL(hook *github.Hook) string {
+	url, _ := hook.Config["url"].(string)
+	return url
+}
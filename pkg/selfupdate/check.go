@@ -0,0 +1,72 @@
+package selfupdate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// CheckInterval is how long the result of Check is cached
+const CheckInterval = 24 * time.Hour
+
+// state is the result of the last Check
+type state struct {
+	CheckedAt  time.Time `json:"checked_at"`
+	Prerelease bool      `json:"prerelease"`
+	Latest     string    `json:"latest,omitempty"`
+}
+
+// Check returns the latest version, it is looked up at most once per CheckInterval and cached in file.
+// Failed lookups are cached as well so commands run offline are not slowed down by each of them.
+func (u *Updater) Check(ctx context.Context, file string) (string, error) {
+	if s, err := readState(file); err == nil && s.Prerelease == u.Prerelease && time.Since(s.CheckedAt) < CheckInterval {
+		return s.Latest, nil
+	}
+
+	s := &state{CheckedAt: time.Now(), Prerelease: u.Prerelease}
+
+	release, err := u.Latest(ctx)
+	if err == nil {
+		s.Latest = release.Version
+	}
+	if errors.Is(err, ErrNoRelease) || errors.Is(err, ErrNoAsset) || errors.Is(err, ErrNoChecksums) {
+		err = nil
+	}
+
+	if writeErr := writeState(file, s); err == nil {
+		err = writeErr
+	}
+
+	return s.Latest, err
+}
+
+func readState(file string) (*state, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var s state
+	if err := json.Unmarshal(b, &s); err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+func writeState(file string, s *state) error {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(file, b, 0600)
+}
@@ -0,0 +1,114 @@
+package selfupdate
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hellofresh/github-cli/pkg/test"
+)
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		state   *state
+		latest  string
+		lookups int
+	}{
+		{
+			name:   "newer release found",
+			latest: "1.1.0",
+		},
+		{
+			name:   "checked recently",
+			state:  &state{CheckedAt: time.Now().Add(-time.Hour), Latest: "1.0.5"},
+			latest: "1.0.5",
+		},
+		{
+			name:   "stale state",
+			state:  &state{CheckedAt: time.Now().Add(-CheckInterval - time.Minute), Latest: "1.0.5"},
+			latest: "1.1.0",
+		},
+		{
+			name:   "checked for another channel",
+			state:  &state{CheckedAt: time.Now().Add(-time.Hour), Prerelease: true, Latest: "1.2.0-rc.1"},
+			latest: "1.1.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := test.NewFakeGithub()
+			u := newFakeUpdater(t, fake)
+			for _, tag := range []string{"v1.0.0", "v1.1.0"} {
+				fake.AddRelease(testOwner, testRepo, tag, false, map[string][]byte{assetName(tag): nil, "checksums.txt": nil})
+			}
+
+			file := filepath.Join(t.TempDir(), "github-cli", "update-check.json")
+			if tt.state != nil {
+				require.NoError(t, writeState(file, tt.state))
+			}
+
+			latest, err := u.Check(context.Background(), file)
+			require.NoError(t, err)
+			assert.Equal(t, tt.latest, latest)
+
+			s, err := readState(file)
+			require.NoError(t, err)
+			assert.Equal(t, tt.latest, s.Latest)
+			assert.False(t, s.Prerelease)
+			assert.WithinDuration(t, time.Now(), s.CheckedAt, CheckInterval)
+
+			if tt.state != nil && tt.state.Latest == tt.latest {
+				assert.Empty(t, fake.Requests(), "the releases are not listed again")
+				assert.Equal(t, tt.state.CheckedAt.Unix(), s.CheckedAt.Unix(), "the state is kept")
+			} else {
+				assert.NotEmpty(t, fake.Requests())
+				assert.WithinDuration(t, time.Now(), s.CheckedAt, time.Minute, "the state is refreshed")
+			}
+		})
+	}
+}
+
+func TestCheckInvalidState(t *testing.T) {
+	fake := test.NewFakeGithub()
+	u := newFakeUpdater(t, fake)
+	fake.AddRelease(testOwner, testRepo, "v1.1.0", false, map[string][]byte{assetName("1.1.0"): nil, "checksums.txt": nil})
+
+	file := filepath.Join(t.TempDir(), "update-check.json")
+	require.NoError(t, ioutil.WriteFile(file, []byte("{"), 0600))
+
+	latest, err := u.Check(context.Background(), file)
+	require.NoError(t, err)
+	assert.Equal(t, "1.1.0", latest)
+}
+
+func TestCheckCachesFailures(t *testing.T) {
+	fake := test.NewFakeGithub()
+	u := newFakeUpdater(t, fake)
+	// a release without an asset for the platform is not reported, nor retried
+	fake.AddRelease(testOwner, testRepo, "v1.1.0", false, map[string][]byte{"checksums.txt": nil})
+
+	file := filepath.Join(t.TempDir(), "update-check.json")
+	for i := 0; i < 2; i++ {
+		latest, err := u.Check(context.Background(), file)
+		require.NoError(t, err)
+		assert.Empty(t, latest)
+	}
+	assert.Len(t, fake.Requests(), 1)
+
+	// the releases of a missing repository can not be listed, the error is reported once
+	u.repo = "missing"
+	missing := filepath.Join(t.TempDir(), "update-check.json")
+	_, err := u.Check(context.Background(), missing)
+	assert.Error(t, err)
+
+	latest, err := u.Check(context.Background(), missing)
+	assert.NoError(t, err)
+	assert.Empty(t, latest)
+}
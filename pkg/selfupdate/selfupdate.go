@@ -0,0 +1,369 @@
+// Package selfupdate replaces the running binary by a verified github-cli release
+package selfupdate
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/google/go-github/v33/github"
+	"github.com/hellofresh/updater-go/v3"
+)
+
+const (
+	// checksumsSuffix is the name goreleaser gives to the checksums file, prefixed by the project and version
+	checksumsSuffix = "checksums.txt"
+	// signatureSuffix is appended to the checksums file name by goreleaser's sign step
+	signatureSuffix = ".sig"
+)
+
+var (
+	// ErrNoRelease is returned when no release matches
+	ErrNoRelease = errors.New("no release found")
+	// ErrNoAsset is returned when the release has nothing for the current platform
+	ErrNoAsset = fmt.Errorf("release has no asset for %s/%s", runtime.GOOS, runtime.GOARCH)
+	// ErrNoChecksums is returned when the release has no checksums file
+	ErrNoChecksums = errors.New("release has no checksums file")
+	// ErrChecksumMismatch is returned when the downloaded asset does not match its checksum
+	ErrChecksumMismatch = errors.New("checksum does not match")
+	// ErrNoSignature is returned when a public key is given but the checksums are not signed
+	ErrNoSignature = errors.New("release checksums are not signed")
+	// ErrNoPrevious is returned on rollback when no previous binary was kept
+	ErrNoPrevious = errors.New("no previous version to roll back to")
+)
+
+type (
+	// Release is a release with the assets needed to update the current platform
+	Release struct {
+		Version   string
+		Asset     *github.ReleaseAsset
+		Checksums *github.ReleaseAsset
+		Signature *github.ReleaseAsset
+	}
+
+	// Updater finds, verifies and installs releases
+	Updater struct {
+		client *github.Client
+		owner  string
+		repo   string
+
+		// Prerelease includes the prereleases when looking for the latest release
+		Prerelease bool
+		// PublicKey is an armored OpenPGP key, when set the checksums must be signed with it
+		PublicKey []byte
+		// Binary is the file replaced by the update, the running executable when empty
+		Binary string
+	}
+)
+
+// New creates an updater for the releases of the given repository
+func New(client *github.Client, owner, repo string) *Updater {
+	return &Updater{client: client, owner: owner, repo: repo}
+}
+
+// Latest returns the release with the highest version, drafts are skipped and so are prereleases unless
+// Prerelease is set
+func (u *Updater) Latest(ctx context.Context) (*Release, error) {
+	var (
+		latest        *github.RepositoryRelease
+		latestVersion *semver.Version
+	)
+
+	opts := &github.ListOptions{PerPage: 50}
+	for {
+		releases, resp, err := u.client.Repositories.ListReleases(ctx, u.owner, u.repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("could not list releases: %w", err)
+		}
+
+		for _, release := range releases {
+			if release.GetDraft() || release.GetPrerelease() && !u.Prerelease {
+				continue
+			}
+
+			v, err := semver.NewVersion(release.GetTagName())
+			if err != nil {
+				continue
+			}
+
+			if latestVersion == nil || v.GreaterThan(latestVersion) {
+				latest, latestVersion = release, v
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	if latest == nil {
+		return nil, ErrNoRelease
+	}
+
+	return newRelease(latest)
+}
+
+// Release returns the release of the given version, with or without the v prefix
+func (u *Updater) Release(ctx context.Context, version string) (*Release, error) {
+	version = strings.TrimPrefix(version, "v")
+
+	for _, tag := range []string{"v" + version, version} {
+		release, _, err := u.client.Repositories.GetReleaseByTag(ctx, u.owner, u.repo, tag)
+		var errResp *github.ErrorResponse
+		if errors.As(err, &errResp) && errResp.Response.StatusCode == http.StatusNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not get release %q: %w", tag, err)
+		}
+
+		return newRelease(release)
+	}
+
+	return nil, fmt.Errorf("%w for version %q", ErrNoRelease, version)
+}
+
+// Apply downloads and verifies the release, then replaces the binary keeping the current one for Rollback
+func (u *Updater) Apply(ctx context.Context, release *Release) error {
+	binary, err := u.binary()
+	if err != nil {
+		return err
+	}
+
+	checksums, err := u.download(ctx, release.Checksums)
+	if err != nil {
+		return err
+	}
+
+	if err := u.verifySignature(ctx, release, checksums); err != nil {
+		return err
+	}
+
+	asset, err := u.download(ctx, release.Asset)
+	if err != nil {
+		return err
+	}
+
+	if err := verifyChecksum(checksums, release.Asset.GetName(), asset); err != nil {
+		return err
+	}
+
+	content, err := extract(release.Asset.GetName(), asset, filepath.Base(binary))
+	if err != nil {
+		return err
+	}
+
+	return install(binary, content)
+}
+
+// Rollback swaps the binary with the one kept by the last update
+func (u *Updater) Rollback() error {
+	binary, err := u.binary()
+	if err != nil {
+		return err
+	}
+
+	previous := previousPath(binary)
+	if _, err := os.Stat(previous); os.IsNotExist(err) {
+		return ErrNoPrevious
+	}
+
+	content, err := ioutil.ReadFile(previous)
+	if err != nil {
+		return fmt.Errorf("could not read previous binary: %w", err)
+	}
+
+	return install(binary, content)
+}
+
+// Newer reports whether candidate is a higher version than current, development builds are always older
+func Newer(current, candidate string) bool {
+	candidateVersion, err := semver.NewVersion(candidate)
+	if err != nil {
+		return false
+	}
+
+	currentVersion, err := semver.NewVersion(current)
+	if err != nil {
+		return true
+	}
+
+	return candidateVersion.GreaterThan(currentVersion)
+}
+
+func newRelease(release *github.RepositoryRelease) (*Release, error) {
+	r := &Release{Version: strings.TrimPrefix(release.GetTagName(), "v")}
+	platform := fmt.Sprintf("_%s_%s", runtime.GOOS, runtime.GOARCH)
+
+	for _, asset := range release.Assets {
+		name := asset.GetName()
+		switch {
+		case strings.HasSuffix(name, checksumsSuffix):
+			r.Checksums = asset
+		case strings.HasSuffix(name, checksumsSuffix+signatureSuffix):
+			r.Signature = asset
+		case strings.Contains(name, platform) && r.Asset == nil:
+			r.Asset = asset
+		}
+	}
+
+	if r.Asset == nil {
+		return nil, fmt.Errorf("version %s: %w", r.Version, ErrNoAsset)
+	}
+	if r.Checksums == nil {
+		return nil, fmt.Errorf("version %s: %w", r.Version, ErrNoChecksums)
+	}
+
+	return r, nil
+}
+
+func (u *Updater) binary() (string, error) {
+	if u.Binary != "" {
+		return u.Binary, nil
+	}
+
+	binary, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("could not find the executable: %w", err)
+	}
+
+	return filepath.EvalSymlinks(binary)
+}
+
+func (u *Updater) download(ctx context.Context, asset *github.ReleaseAsset) ([]byte, error) {
+	rc, _, err := u.client.Repositories.DownloadReleaseAsset(ctx, u.owner, u.repo, asset.GetID(), http.DefaultClient)
+	if err != nil {
+		return nil, fmt.Errorf("could not download %s: %w", asset.GetName(), err)
+	}
+	defer rc.Close()
+
+	content, err := ioutil.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("could not download %s: %w", asset.GetName(), err)
+	}
+
+	return content, nil
+}
+
+func (u *Updater) verifySignature(ctx context.Context, release *Release, checksums []byte) error {
+	if len(u.PublicKey) == 0 {
+		return nil
+	}
+	if release.Signature == nil {
+		return ErrNoSignature
+	}
+
+	keyring, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(u.PublicKey))
+	if err != nil {
+		return fmt.Errorf("could not read public key: %w", err)
+	}
+
+	signature, err := u.download(ctx, release.Signature)
+	if err != nil {
+		return err
+	}
+
+	check := openpgp.CheckDetachedSignature
+	if bytes.HasPrefix(bytes.TrimSpace(signature), []byte("-----BEGIN")) {
+		check = openpgp.CheckArmoredDetachedSignature
+	}
+
+	if _, err := check(keyring, bytes.NewReader(checksums), bytes.NewReader(signature), nil); err != nil {
+		return fmt.Errorf("invalid signature of %s: %w", release.Checksums.GetName(), err)
+	}
+
+	return nil
+}
+
+// verifyChecksum checks the content against the sha256sum formatted checksums
+func verifyChecksum(checksums []byte, name string, content []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || strings.TrimPrefix(fields[1], "*") != name {
+			continue
+		}
+
+		sum := sha256.Sum256(content)
+		if !strings.EqualFold(fields[0], hex.EncodeToString(sum[:])) {
+			return fmt.Errorf("%s: %w", name, ErrChecksumMismatch)
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("%s is not in the checksums file: %w", name, ErrChecksumMismatch)
+}
+
+// extract returns the binary from the archive, assets that are not archives are the binary itself
+func extract(asset string, content []byte, binary string) ([]byte, error) {
+	extractor := updater.MatchingExtractor(asset)
+	if extractor == nil {
+		return content, nil
+	}
+
+	r, err := extractor.FetchBinary(bytes.NewReader(content), func(info os.FileInfo) bool {
+		return !info.IsDir() && filepath.Base(info.Name()) == binary
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not find %s in %s: %w", binary, asset, err)
+	}
+
+	return ioutil.ReadAll(r)
+}
+
+// install replaces the binary by content, the replaced binary is kept at previousPath
+func install(binary string, content []byte) error {
+	info, err := os.Stat(binary)
+	if err != nil {
+		return err
+	}
+
+	dir, name := filepath.Split(binary)
+	newPath := filepath.Join(dir, fmt.Sprintf(".%s.new", name))
+	previous := previousPath(binary)
+	replaced := filepath.Join(dir, fmt.Sprintf(".%s.replaced", name))
+
+	if err := ioutil.WriteFile(newPath, content, info.Mode()); err != nil {
+		return fmt.Errorf("could not write new binary: %w", err)
+	}
+	defer os.Remove(newPath)
+
+	// the binary is moved aside before the previous one is replaced, so a rollback swaps both
+	os.Remove(replaced)
+	if err := os.Rename(binary, replaced); err != nil {
+		return fmt.Errorf("could not move current binary: %w", err)
+	}
+
+	if err := os.Rename(newPath, binary); err != nil {
+		if rollbackErr := os.Rename(replaced, binary); rollbackErr != nil {
+			return fmt.Errorf("could not restore %s from %s after %v: %w", binary, replaced, err, rollbackErr)
+		}
+		return fmt.Errorf("could not install new binary: %w", err)
+	}
+
+	os.Remove(previous)
+	if err := os.Rename(replaced, previous); err != nil {
+		return fmt.Errorf("could not keep previous binary: %w", err)
+	}
+
+	return nil
+}
+
+func previousPath(binary string) string {
+	dir, name := filepath.Split(binary)
+	return filepath.Join(dir, fmt.Sprintf(".%s.previous", name))
+}
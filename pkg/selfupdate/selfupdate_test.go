@@ -0,0 +1,366 @@
+package selfupdate
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	"github.com/google/go-github/v33/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hellofresh/github-cli/pkg/test"
+)
+
+const (
+	testOwner = "hellofresh"
+	testRepo  = "github-cli"
+)
+
+// assetName is the name of the release asset of the current platform
+func assetName(version string) string {
+	return fmt.Sprintf("github-cli_%s_%s_%s", version, runtime.GOOS, runtime.GOARCH)
+}
+
+// checksumsOf returns a checksums file as written by goreleaser
+func checksumsOf(files map[string][]byte) []byte {
+	var b bytes.Buffer
+	for name, content := range files {
+		sum := sha256.Sum256(content)
+		fmt.Fprintf(&b, "%s  %s\n", hex.EncodeToString(sum[:]), name)
+	}
+
+	return b.Bytes()
+}
+
+// newKey returns a signing key and its armored public key
+func newKey(t *testing.T) (*openpgp.Entity, []byte) {
+	t.Helper()
+
+	entity, err := openpgp.NewEntity("github-cli", "", "github-cli@example.com", &packet.Config{Algorithm: packet.PubKeyAlgoEdDSA})
+	require.NoError(t, err)
+
+	var b bytes.Buffer
+	w, err := armor.Encode(&b, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.Serialize(w))
+	require.NoError(t, w.Close())
+
+	return entity, b.Bytes()
+}
+
+// newFakeUpdater returns an updater reading the releases of the fake, the binary it replaces is in a temp dir
+func newFakeUpdater(t *testing.T, fake *test.FakeGithub) *Updater {
+	t.Helper()
+
+	fake.AddOrg(testOwner)
+	server := fake.Start()
+	t.Cleanup(server.Close)
+	fake.AddRepo(testOwner, testRepo, false)
+
+	u := New(fake.Client(), testOwner, testRepo)
+	u.Binary = filepath.Join(t.TempDir(), "github-cli")
+	require.NoError(t, ioutil.WriteFile(u.Binary, []byte("current"), 0755))
+
+	return u
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	content := []byte("binary")
+	sum := sha256.Sum256(content)
+	hash := hex.EncodeToString(sum[:])
+
+	tests := []struct {
+		name      string
+		checksums string
+		err       string
+	}{
+		{name: "match", checksums: "0000  other\n" + hash + "  github-cli_linux_amd64\n"},
+		{name: "upper case", checksums: fmt.Sprintf("%X  github-cli_linux_amd64\n", sum)},
+		{name: "binary mode entry", checksums: hash + " *github-cli_linux_amd64\n"},
+		{name: "mismatch", checksums: "0000  github-cli_linux_amd64\n", err: "github-cli_linux_amd64: checksum does not match"},
+		{name: "missing entry", checksums: hash + "  github-cli_darwin_amd64\n", err: "github-cli_linux_amd64 is not in the checksums file: checksum does not match"},
+		{name: "prefix of the name", checksums: hash + "  github-cli_linux_amd64.tar.gz\n", err: "github-cli_linux_amd64 is not in the checksums file: checksum does not match"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyChecksum([]byte(tt.checksums), "github-cli_linux_amd64", content)
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, tt.err)
+			assert.ErrorIs(t, err, ErrChecksumMismatch)
+		})
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	entity, publicKey := newKey(t)
+	_, otherKey := newKey(t)
+	checksums := []byte("0000  github-cli_linux_amd64\n")
+
+	sign := func(armored bool, content []byte) []byte {
+		var b bytes.Buffer
+		if armored {
+			require.NoError(t, openpgp.ArmoredDetachSign(&b, entity, bytes.NewReader(content), nil))
+		} else {
+			require.NoError(t, openpgp.DetachSign(&b, entity, bytes.NewReader(content), nil))
+		}
+		return b.Bytes()
+	}
+
+	tests := []struct {
+		name      string
+		publicKey []byte
+		signature []byte
+		err       string
+	}{
+		{name: "no key", signature: []byte("not a signature")},
+		{name: "no key nor signature"},
+		{name: "missing signature", publicKey: publicKey, err: ErrNoSignature.Error()},
+		{name: "binary signature", publicKey: publicKey, signature: sign(false, checksums)},
+		{name: "armored signature", publicKey: publicKey, signature: sign(true, checksums)},
+		{name: "signature of other checksums", publicKey: publicKey, signature: sign(false, []byte("tampered")), err: "invalid signature of checksums.txt: openpgp: invalid signature: hash tag doesn't match"},
+		{name: "signed with another key", publicKey: otherKey, signature: sign(true, checksums), err: "invalid signature of checksums.txt: openpgp: signature made by unknown entity"},
+		{name: "invalid key", publicKey: []byte("not a key"), signature: sign(true, checksums), err: "could not read public key: openpgp: invalid argument: no armored data found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := test.NewFakeGithub()
+			u := newFakeUpdater(t, fake)
+			u.PublicKey = tt.publicKey
+
+			assets := map[string][]byte{assetName("1.0.0"): []byte("binary"), "checksums.txt": checksums}
+			if tt.signature != nil {
+				assets["checksums.txt.sig"] = tt.signature
+			}
+			release, err := newRelease(fake.AddRelease(testOwner, testRepo, "v1.0.0", false, assets))
+			require.NoError(t, err)
+
+			err = u.verifySignature(context.Background(), release, checksums)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestNewRelease(t *testing.T) {
+	assets := func(names ...string) []*github.ReleaseAsset {
+		var assets []*github.ReleaseAsset
+		for _, name := range names {
+			assets = append(assets, &github.ReleaseAsset{Name: github.String(name)})
+		}
+		return assets
+	}
+
+	tests := []struct {
+		name      string
+		assets    []*github.ReleaseAsset
+		asset     string
+		checksums string
+		signature string
+		err       error
+	}{
+		{
+			name:      "signed",
+			assets:    assets("github-cli_1.2.0_checksums.txt", "github-cli_1.2.0_checksums.txt.sig", "github-cli_1.2.0_plan9_386.tar.gz", assetName("1.2.0")+".tar.gz"),
+			asset:     assetName("1.2.0") + ".tar.gz",
+			checksums: "github-cli_1.2.0_checksums.txt",
+			signature: "github-cli_1.2.0_checksums.txt.sig",
+		},
+		{
+			name:      "not signed",
+			assets:    assets(assetName("1.2.0")+".zip", assetName("1.2.0")+".tar.gz", "checksums.txt"),
+			asset:     assetName("1.2.0") + ".zip",
+			checksums: "checksums.txt",
+		},
+		{
+			name:   "no asset for the platform",
+			assets: assets("github-cli_1.2.0_plan9_386.tar.gz", "checksums.txt"),
+			err:    ErrNoAsset,
+		},
+		{
+			name:   "no checksums",
+			assets: assets(assetName("1.2.0"), "checksums.txt.sig"),
+			err:    ErrNoChecksums,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			release, err := newRelease(&github.RepositoryRelease{TagName: github.String("v1.2.0"), Assets: tt.assets})
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				assert.Contains(t, err.Error(), "version 1.2.0: ")
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, "1.2.0", release.Version)
+			assert.Equal(t, tt.asset, release.Asset.GetName())
+			assert.Equal(t, tt.checksums, release.Checksums.GetName())
+			assert.Equal(t, tt.signature, release.Signature.GetName())
+		})
+	}
+}
+
+func TestLatest(t *testing.T) {
+	fake := test.NewFakeGithub()
+	// the releases are listed across pages
+	fake.PerPage = 1
+	u := newFakeUpdater(t, fake)
+
+	_, err := u.Latest(context.Background())
+	assert.ErrorIs(t, err, ErrNoRelease)
+
+	for _, tag := range []string{"v1.0.0", "v1.1.0", "nightly", "v1.2.0-rc.1", "v2.0.0"} {
+		fake.AddRelease(testOwner, testRepo, tag, tag == "v1.2.0-rc.1", map[string][]byte{
+			assetName(tag):  []byte(tag),
+			"checksums.txt": checksumsOf(map[string][]byte{assetName(tag): []byte(tag)}),
+		})
+	}
+	fake.Repo(testOwner, testRepo).Releases[4].Draft = github.Bool(true)
+
+	release, err := u.Latest(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "1.1.0", release.Version)
+
+	u.Prerelease = true
+	release, err = u.Latest(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.0-rc.1", release.Version)
+}
+
+func TestRelease(t *testing.T) {
+	fake := test.NewFakeGithub()
+	u := newFakeUpdater(t, fake)
+	fake.AddRelease(testOwner, testRepo, "v1.0.0", false, map[string][]byte{assetName("1.0.0"): nil, "checksums.txt": nil})
+	fake.AddRelease(testOwner, testRepo, "0.9.0", false, map[string][]byte{assetName("0.9.0"): nil, "checksums.txt": nil})
+
+	for _, version := range []string{"1.0.0", "v1.0.0", "0.9.0", "v0.9.0"} {
+		release, err := u.Release(context.Background(), version)
+		require.NoError(t, err, version)
+		assert.Equal(t, version[len(version)-5:], release.Version)
+	}
+
+	_, err := u.Release(context.Background(), "2.0.0")
+	assert.ErrorIs(t, err, ErrNoRelease)
+	assert.EqualError(t, err, `no release found for version "2.0.0"`)
+}
+
+func TestApply(t *testing.T) {
+	binary := []byte("new binary")
+
+	tests := []struct {
+		name      string
+		checksums []byte
+		err       error
+		content   string
+	}{
+		{name: "verified", checksums: checksumsOf(map[string][]byte{assetName("1.0.0"): binary}), content: "new binary"},
+		{name: "checksum mismatch", checksums: checksumsOf(map[string][]byte{assetName("1.0.0"): []byte("other")}), err: ErrChecksumMismatch, content: "current"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := test.NewFakeGithub()
+			u := newFakeUpdater(t, fake)
+			release, err := newRelease(fake.AddRelease(testOwner, testRepo, "v1.0.0", false, map[string][]byte{
+				assetName("1.0.0"): binary,
+				"checksums.txt":    tt.checksums,
+			}))
+			require.NoError(t, err)
+
+			err = u.Apply(context.Background(), release)
+			assert.ErrorIs(t, err, tt.err)
+
+			content, err := ioutil.ReadFile(u.Binary)
+			require.NoError(t, err)
+			assert.Equal(t, tt.content, string(content))
+		})
+	}
+}
+
+func TestInstallAndRollback(t *testing.T) {
+	u := &Updater{Binary: filepath.Join(t.TempDir(), "github-cli")}
+	require.NoError(t, ioutil.WriteFile(u.Binary, []byte("1.0.0"), 0755))
+
+	assert.ErrorIs(t, u.Rollback(), ErrNoPrevious)
+
+	files := func() (string, string) {
+		t.Helper()
+
+		current, err := ioutil.ReadFile(u.Binary)
+		require.NoError(t, err)
+		previous, err := ioutil.ReadFile(previousPath(u.Binary))
+		require.NoError(t, err)
+
+		return string(current), string(previous)
+	}
+
+	require.NoError(t, install(u.Binary, []byte("1.1.0")))
+	current, previous := files()
+	assert.Equal(t, "1.1.0", current)
+	assert.Equal(t, "1.0.0", previous)
+	assert.Equal(t, filepath.Join(filepath.Dir(u.Binary), ".github-cli.previous"), previousPath(u.Binary))
+
+	require.NoError(t, u.Rollback())
+	current, previous = files()
+	assert.Equal(t, "1.0.0", current)
+	assert.Equal(t, "1.1.0", previous, "rolling back again undoes the rollback")
+
+	require.NoError(t, u.Rollback())
+	current, _ = files()
+	assert.Equal(t, "1.1.0", current)
+
+	info, err := os.Stat(u.Binary)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0755), info.Mode().Perm(), "the mode of the binary is kept")
+
+	entries, err := ioutil.ReadDir(filepath.Dir(u.Binary))
+	require.NoError(t, err)
+	assert.Len(t, entries, 2, "no temporary file is left")
+}
+
+func TestNewer(t *testing.T) {
+	tests := []struct {
+		current   string
+		candidate string
+		newer     bool
+	}{
+		{"1.0.0", "1.1.0", true},
+		{"v1.0.0", "1.0.1", true},
+		{"1.1.0", "1.0.0", false},
+		{"1.1.0", "1.1.0", false},
+		{"1.1.0", "1.2.0-rc.1", true},
+		{"1.2.0-rc.1", "1.2.0", true},
+		{"1.2.0", "1.2.0-rc.1", false},
+		{"dev", "1.0.0", true},
+		{"", "1.0.0", true},
+		{"1.0.0", "nightly", false},
+		{"1.0.0", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.current+" "+tt.candidate, func(t *testing.T) {
+			assert.Equal(t, tt.newer, Newer(tt.current, tt.candidate))
+		})
+	}
+}
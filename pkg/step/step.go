@@ -0,0 +1,159 @@
+package step
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+type (
+	// Step is a unit of work that runs once all the steps it depends on succeeded
+	Step struct {
+		Name      string
+		DependsOn []string
+		Run       func(ctx context.Context) error
+	}
+
+	// Status is the outcome of a step
+	Status string
+
+	// Result describes how a step ended
+	Result struct {
+		Name     string
+		Status   Status
+		Err      error
+		Duration time.Duration
+	}
+
+	// Engine runs steps concurrently, following their dependencies
+	Engine struct {
+		steps []*Step
+	}
+)
+
+const (
+	// StatusSucceeded is used when the step ran without errors
+	StatusSucceeded Status = "succeeded"
+	// StatusFailed is used when the step returned an error
+	StatusFailed Status = "failed"
+	// StatusSkipped is used when a step it depends on did not succeed
+	StatusSkipped Status = "skipped"
+)
+
+// New creates an engine without steps
+func New() *Engine {
+	return &Engine{}
+}
+
+// Add adds a step that runs after the steps named in dependsOn succeeded
+func (e *Engine) Add(name string, run func(ctx context.Context) error, dependsOn ...string) {
+	e.steps = append(e.steps, &Step{Name: name, DependsOn: dependsOn, Run: run})
+}
+
+// Run runs all the steps, independent steps run concurrently. A failed step does not stop the others,
+// only the ones depending on it are skipped. Results are returned in the order the steps were added,
+// and the errors of the failed steps are returned together.
+func (e *Engine) Run(ctx context.Context) ([]*Result, error) {
+	if err := e.check(); err != nil {
+		return nil, err
+	}
+
+	results := make(map[string]*Result, len(e.steps))
+	done := make(map[string]chan struct{}, len(e.steps))
+	for _, s := range e.steps {
+		results[s.Name] = &Result{Name: s.Name}
+		done[s.Name] = make(chan struct{})
+	}
+
+	var wg sync.WaitGroup
+	for _, s := range e.steps {
+		wg.Add(1)
+		go func(s *Step) {
+			defer wg.Done()
+			defer close(done[s.Name])
+
+			result := results[s.Name]
+			for _, dependency := range s.DependsOn {
+				<-done[dependency]
+				// the result of a dependency is final once its channel is closed
+				if results[dependency].Status != StatusSucceeded {
+					result.Status = StatusSkipped
+					result.Err = fmt.Errorf("%s did not succeed", dependency)
+					return
+				}
+			}
+
+			start := time.Now()
+			result.Err = s.Run(ctx)
+			result.Duration = time.Since(start)
+			result.Status = StatusSucceeded
+			if result.Err != nil {
+				result.Status = StatusFailed
+			}
+		}(s)
+	}
+	wg.Wait()
+
+	var err error
+	ordered := make([]*Result, 0, len(e.steps))
+	for _, s := range e.steps {
+		result := results[s.Name]
+		if result.Status == StatusFailed {
+			err = multierror.Append(err, result.Err)
+		}
+		ordered = append(ordered, result)
+	}
+
+	return ordered, err
+}
+
+// check rejects duplicated steps, unknown dependencies and cycles, which would never finish
+func (e *Engine) check() error {
+	steps := make(map[string]*Step, len(e.steps))
+	for _, s := range e.steps {
+		if _, ok := steps[s.Name]; ok {
+			return fmt.Errorf("step %q is added twice", s.Name)
+		}
+		steps[s.Name] = s
+	}
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int, len(e.steps))
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visiting:
+			return fmt.Errorf("step %q depends on itself", name)
+		case visited:
+			return nil
+		}
+
+		state[name] = visiting
+		for _, dependency := range steps[name].DependsOn {
+			if _, ok := steps[dependency]; !ok {
+				return fmt.Errorf("step %q depends on unknown step %q", name, dependency)
+			}
+			if err := visit(dependency); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+
+		return nil
+	}
+
+	for _, s := range e.steps {
+		if err := visit(s.Name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package step
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder records the order in which steps ran
+type recorder struct {
+	mu    sync.Mutex
+	order []string
+}
+
+func (r *recorder) step(name string, err error) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		r.order = append(r.order, name)
+		return err
+	}
+}
+
+func (r *recorder) index(name string) int {
+	for i, n := range r.order {
+		if n == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func statuses(results []*Result) map[string]Status {
+	s := make(map[string]Status, len(results))
+	for _, result := range results {
+		s[result.Name] = result.Status
+	}
+
+	return s
+}
+
+func TestRunFollowsDependencies(t *testing.T) {
+	rec := &recorder{}
+
+	steps := New()
+	steps.Add("protections", rec.step("protections", nil), "default branch")
+	steps.Add("create", rec.step("create", nil))
+	steps.Add("labels", rec.step("labels", nil), "create")
+	steps.Add("default branch", rec.step("default branch", nil), "create", "labels")
+
+	results, err := steps.Run(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, rec.order, 4)
+	assert.Less(t, rec.index("create"), rec.index("labels"))
+	assert.Less(t, rec.index("labels"), rec.index("default branch"))
+	assert.Less(t, rec.index("default branch"), rec.index("protections"))
+
+	var names []string
+	for _, result := range results {
+		names = append(names, result.Name)
+		assert.Equal(t, StatusSucceeded, result.Status)
+		assert.NoError(t, result.Err)
+	}
+	assert.Equal(t, []string{"protections", "create", "labels", "default branch"}, names, "results keep the order the steps were added")
+}
+
+func TestRunIndependentStepsConcurrently(t *testing.T) {
+	const count = 3
+
+	// every step waits for all the others to start, which only finishes when they run concurrently
+	var started sync.WaitGroup
+	started.Add(count)
+	all := make(chan struct{})
+	go func() {
+		started.Wait()
+		close(all)
+	}()
+
+	steps := New()
+	for _, name := range []string{"labels", "teams", "collaborators"} {
+		steps.Add(name, func(ctx context.Context) error {
+			started.Done()
+			select {
+			case <-all:
+				return nil
+			case <-time.After(5 * time.Second):
+				return errors.New("steps did not run concurrently")
+			}
+		})
+	}
+
+	_, err := steps.Run(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestRunPropagatesErrors(t *testing.T) {
+	rec := &recorder{}
+	errCreate := errors.New("create failed")
+	errTeams := errors.New("teams failed")
+
+	steps := New()
+	steps.Add("create", rec.step("create", errCreate))
+	steps.Add("labels", rec.step("labels", nil), "create")
+	steps.Add("protections", rec.step("protections", nil), "labels")
+	steps.Add("teams", rec.step("teams", errTeams))
+	steps.Add("collaborators", rec.step("collaborators", nil))
+
+	results, err := steps.Run(context.Background())
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errCreate))
+	assert.True(t, errors.Is(err, errTeams))
+
+	assert.Equal(t, map[string]Status{
+		"create":        StatusFailed,
+		"labels":        StatusSkipped,
+		"protections":   StatusSkipped,
+		"teams":         StatusFailed,
+		"collaborators": StatusSucceeded,
+	}, statuses(results))
+	assert.ElementsMatch(t, []string{"create", "teams", "collaborators"}, rec.order, "skipped steps must not run")
+	assert.EqualError(t, results[1].Err, "create did not succeed")
+}
+
+func TestRunRejectsInvalidSteps(t *testing.T) {
+	noop := func(ctx context.Context) error { return nil }
+
+	tests := []struct {
+		name  string
+		add   func(e *Engine)
+		error string
+	}{
+		{
+			name: "duplicated step",
+			add: func(e *Engine) {
+				e.Add("create", noop)
+				e.Add("create", noop)
+			},
+			error: `step "create" is added twice`,
+		},
+		{
+			name: "unknown dependency",
+			add: func(e *Engine) {
+				e.Add("labels", noop, "create")
+			},
+			error: `step "labels" depends on unknown step "create"`,
+		},
+		{
+			name: "cycle",
+			add: func(e *Engine) {
+				e.Add("a", noop, "b")
+				e.Add("b", noop, "a")
+			},
+			error: `step "a" depends on itself`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			steps := New()
+			tt.add(steps)
+
+			results, err := steps.Run(context.Background())
+			assert.EqualError(t, err, tt.error)
+			assert.Nil(t, results)
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/pktline"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/protocol/packp"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/server"
+	"github.com/go-git/go-git/v5/storage/memory"
+)
+
+// gitLoader gives the git server access to the repositories of the fake
+type gitLoader struct {
+	fake *FakeGithub
+}
+
+// Load implements server.Loader, endpoints are /<owner>/<repo>.git
+func (l *gitLoader) Load(ep *transport.Endpoint) (storer.Storer, error) {
+	parts := strings.Split(strings.Trim(strings.TrimSuffix(ep.Path, ".git"), "/"), "/")
+	if len(parts) != 2 {
+		return nil, transport.ErrRepositoryNotFound
+	}
+
+	r := l.fake.repo(parts[0], parts[1])
+	if r == nil {
+		return nil, transport.ErrRepositoryNotFound
+	}
+
+	return r.git, nil
+}
+
+// initRepository creates the first commit of an auto initialized repository
+func initRepository(storage *memory.Storage, name string, branch string) error {
+	r, err := git.Init(storage, memfs.New())
+	if err != nil {
+		return err
+	}
+
+	head := plumbing.NewSymbolicReference(plumbing.HEAD, plumbing.NewBranchReferenceName(branch))
+	if err := storage.SetReference(head); err != nil {
+		return err
+	}
+
+	wt, err := r.Worktree()
+	if err != nil {
+		return err
+	}
+
+	f, err := wt.Filesystem.Create("README.md")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(f, "# %s\n", name)
+	f.Close()
+
+	if _, err := wt.Add("README.md"); err != nil {
+		return err
+	}
+
+	_, err = wt.Commit("Initial commit", &git.CommitOptions{
+		Author: &object.Signature{Name: "github", Email: "noreply@github.com", When: time.Now()},
+	})
+
+	return err
+}
+
+// serveGit implements the git smart HTTP protocol, enough for go-git to clone, fetch and push.
+// Shallow clones are not supported.
+func (f *FakeGithub) serveGit(method string, path string, service string, body []byte) Response {
+	repoPath := path
+	for _, suffix := range []string{"/info/refs", "/git-upload-pack", "/git-receive-pack"} {
+		repoPath = strings.TrimSuffix(repoPath, suffix)
+	}
+
+	ep, err := transport.NewEndpoint(f.URL + repoPath)
+	if err != nil {
+		return gitError(http.StatusBadRequest, err)
+	}
+
+	srv := server.NewServer(&gitLoader{fake: f})
+	ctx := context.Background()
+	var out bytes.Buffer
+
+	switch {
+	case method == http.MethodGet && strings.HasSuffix(path, "/info/refs"):
+		var (
+			ar  *packp.AdvRefs
+			err error
+		)
+		switch service {
+		case transport.UploadPackServiceName:
+			var sess transport.UploadPackSession
+			if sess, err = srv.NewUploadPackSession(ep, nil); err == nil {
+				ar, err = sess.AdvertisedReferencesContext(ctx)
+			}
+		case transport.ReceivePackServiceName:
+			var sess transport.ReceivePackSession
+			if sess, err = srv.NewReceivePackSession(ep, nil); err == nil {
+				ar, err = sess.AdvertisedReferencesContext(ctx)
+			}
+		default:
+			return gitError(http.StatusForbidden, fmt.Errorf("dumb http is not supported"))
+		}
+		if err != nil {
+			return gitError(http.StatusNotFound, err)
+		}
+
+		ar.Prefix = [][]byte{[]byte(fmt.Sprintf("# service=%s", service)), pktline.Flush}
+		if err := ar.Encode(&out); err != nil {
+			return gitError(http.StatusInternalServerError, err)
+		}
+
+		return gitResponse(fmt.Sprintf("application/x-%s-advertisement", service), out.Bytes())
+
+	case method == http.MethodPost && strings.HasSuffix(path, "/git-upload-pack"):
+		req := packp.NewUploadPackRequest()
+		if err := req.Decode(bytes.NewReader(body)); err != nil {
+			return gitError(http.StatusBadRequest, err)
+		}
+
+		sess, err := srv.NewUploadPackSession(ep, nil)
+		if err != nil {
+			return gitError(http.StatusNotFound, err)
+		}
+
+		resp, err := sess.UploadPack(ctx, req)
+		if err != nil {
+			return gitError(http.StatusInternalServerError, err)
+		}
+		if err := resp.Encode(&out); err != nil {
+			return gitError(http.StatusInternalServerError, err)
+		}
+
+		return gitResponse("application/x-git-upload-pack-result", out.Bytes())
+
+	case method == http.MethodPost && strings.HasSuffix(path, "/git-receive-pack"):
+		req := packp.NewReferenceUpdateRequest()
+		if err := req.Decode(bytes.NewReader(body)); err != nil {
+			return gitError(http.StatusBadRequest, err)
+		}
+
+		sess, err := srv.NewReceivePackSession(ep, nil)
+		if err != nil {
+			return gitError(http.StatusNotFound, err)
+		}
+
+		status, err := sess.ReceivePack(ctx, req)
+		if status != nil {
+			if encErr := status.Encode(&out); encErr != nil {
+				return gitError(http.StatusInternalServerError, encErr)
+			}
+		} else if err != nil {
+			return gitError(http.StatusInternalServerError, err)
+		}
+
+		return gitResponse("application/x-git-receive-pack-result", out.Bytes())
+	}
+
+	return gitError(http.StatusNotFound, fmt.Errorf("unknown git endpoint %s %s", method, path))
+}
+
+func gitResponse(contentType string, body []byte) Response {
+	return Response{
+		Header: http.Header{"Content-Type": []string{contentType}, "Cache-Control": []string{"no-cache"}},
+		Body:   body,
+	}
+}
+
+func gitError(status int, err error) Response {
+	return Response{Status: status, Body: []byte(err.Error())}
+}
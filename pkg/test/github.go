@@ -0,0 +1,1362 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/google/go-github/v33/github"
+)
+
+type (
+	// FakeGithub is a stateful, in-memory fake of the github REST endpoints used by github-cli, and of
+	// git smart HTTP for its repositories. It implements Handler:
+	//
+	//	fake := test.NewFakeGithub()
+	//	server := fake.Start()
+	//	defer server.Close()
+	//	client := fake.Client()
+	//
+	// The API is served both at the root and under /api/v3/, so it can be configured as a GitHub
+	// Enterprise BaseURL. Lists are paginated like github does, with the page and per_page parameters
+	// and a Link header pointing to the other pages.
+	FakeGithub struct {
+		// URL is where the fake is served, used for the urls of the repositories
+		URL string
+		// Login is the user the token belongs to
+		Login string
+		// PerPage caps the size of the pages of lists, set it low to go through several pages
+		PerPage int
+
+		mu       sync.Mutex
+		nextID   int64
+		orgs     map[string]*fakeOrg
+		requests []string
+		// current is the url of the request being handled
+		current *url.URL
+	}
+
+	fakeOrg struct {
+		id      int64
+		login   string
+		members map[string]bool
+		teams   map[string]*github.Team
+		repos   map[string]*FakeRepo
+	}
+
+	// FakeRepo is a repository of the fake, its fields can be checked once a command ran
+	FakeRepo struct {
+		// Data is the repository as returned by the API
+		Data map[string]interface{}
+		// Collaborators are the permissions of the users with direct access, by login
+		Collaborators map[string]string
+		Invitations   []*github.RepositoryInvitation
+		// Teams are the permissions of the teams, by slug
+		Teams  map[string]string
+		Labels []*github.Label
+		// Issues are the label names of the issues and pull requests, by number
+		Issues      map[int][]string
+		Hooks       []*github.Hook
+		Deliveries  map[int64][]map[string]interface{}
+		Protections map[string]*github.Protection
+		Releases    []*github.RepositoryRelease
+		// Assets are the contents of the release assets, by ID
+		Assets map[int64][]byte
+
+		VulnerabilityAlerts bool
+		SecurityFixes       bool
+		Pages               bool
+
+		git *memory.Storage
+	}
+
+	fakeRoute struct {
+		method  string
+		pattern []string
+		handle  func(p map[string]string, q url.Values, body []byte) Response
+	}
+)
+
+// NewFakeGithub creates a fake without organizations, the token belongs to "github-cli"
+func NewFakeGithub() *FakeGithub {
+	return &FakeGithub{
+		Login:  "github-cli",
+		nextID: 1000,
+		orgs:   make(map[string]*fakeOrg),
+	}
+}
+
+// Start serves the fake on a new local server, which needs to be Closed
+func (f *FakeGithub) Start() *Server {
+	s := NewServer(f)
+	f.URL = s.URL()
+
+	return s
+}
+
+// Client returns a github client talking to the started fake
+func (f *FakeGithub) Client() *github.Client {
+	client := github.NewClient(nil)
+	client.BaseURL, _ = url.Parse(f.URL + "/")
+
+	return client
+}
+
+// AddOrg creates an organization
+func (f *FakeGithub) AddOrg(login string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.orgs[strings.ToLower(login)] = &fakeOrg{
+		id:      f.id(),
+		login:   login,
+		members: make(map[string]bool),
+		teams:   make(map[string]*github.Team),
+		repos:   make(map[string]*FakeRepo),
+	}
+}
+
+// AddMember makes a user member of an organization, members get access to repositories without invitation
+func (f *FakeGithub) AddMember(org string, login string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.orgs[strings.ToLower(org)].members[strings.ToLower(login)] = true
+}
+
+// AddTeam creates a team in an organization and returns its ID
+func (f *FakeGithub) AddTeam(org string, slug string) int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := f.id()
+	f.orgs[strings.ToLower(org)].teams[slug] = &github.Team{ID: github.Int64(id), Slug: github.String(slug), Name: github.String(slug)}
+
+	return id
+}
+
+// AddRepo creates a repository, with a first commit on main when autoInit is set
+func (f *FakeGithub) AddRepo(org string, name string, autoInit bool) *FakeRepo {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	r, err := f.createRepo(f.orgs[strings.ToLower(org)], map[string]interface{}{"name": name, "auto_init": autoInit})
+	if err != nil {
+		panic(err)
+	}
+
+	return r
+}
+
+// AddDelivery records a delivery of a webhook
+func (f *FakeGithub) AddDelivery(org string, repo string, hookID int64, statusCode int, event string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.repo(org, repo).deliver(f.id(), hookID, statusCode, event, false)
+}
+
+// AddRelease publishes a release with the given assets, by name
+func (f *FakeGithub) AddRelease(org string, repo string, tag string, prerelease bool, assets map[string][]byte) *github.RepositoryRelease {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	r := f.repo(org, repo)
+	if r.Assets == nil {
+		r.Assets = make(map[int64][]byte)
+	}
+
+	release := &github.RepositoryRelease{
+		ID:         github.Int64(f.id()),
+		TagName:    github.String(tag),
+		Prerelease: github.Bool(prerelease),
+		Draft:      github.Bool(false),
+	}
+
+	names := make([]string, 0, len(assets))
+	for name := range assets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		id := f.id()
+		r.Assets[id] = assets[name]
+		release.Assets = append(release.Assets, &github.ReleaseAsset{ID: github.Int64(id), Name: github.String(name)})
+	}
+	r.Releases = append(r.Releases, release)
+
+	return release
+}
+
+// Repo returns a repository of the fake, nil when it does not exist
+func (f *FakeGithub) Repo(org string, name string) *FakeRepo {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.repo(org, name)
+}
+
+// Requests returns the requests received so far, as "METHOD /path"
+func (f *FakeGithub) Requests() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]string{}, f.requests...)
+}
+
+// Handle implements Handler
+func (f *FakeGithub) Handle(method, path string, header *http.Header, body []byte) Response {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	u, err := url.Parse(path)
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, err.Error())
+	}
+
+	apiPath := strings.TrimPrefix(u.Path, "/api/v3")
+	f.requests = append(f.requests, method+" "+apiPath)
+	f.current = u
+
+	for _, suffix := range []string{"/info/refs", "/git-upload-pack", "/git-receive-pack"} {
+		if strings.HasSuffix(u.Path, suffix) {
+			return f.serveGit(method, u.Path, u.Query().Get("service"), body)
+		}
+	}
+
+	segments := strings.Split(strings.Trim(apiPath, "/"), "/")
+	for _, route := range f.routes() {
+		if params, ok := route.match(method, segments); ok {
+			return route.handle(params, u.Query(), body)
+		}
+	}
+
+	return errorResponse(http.StatusNotFound, "Not Found")
+}
+
+func (f *FakeGithub) routes() []fakeRoute {
+	return []fakeRoute{
+		route("GET /user", f.getUser),
+		route("GET /users/{user}", f.getUser),
+		route("GET /orgs/{org}", f.getOrg),
+		route("GET /orgs/{org}/teams", f.listTeams),
+		route("GET /orgs/{org}/teams/{team}/repos", f.listTeamRepos),
+		route("PUT /organizations/{orgID}/team/{teamID}/repos/{owner}/{repo}", f.addTeamRepo),
+		route("GET /orgs/{org}/repos", f.listRepos),
+		route("POST /orgs/{org}/repos", f.postRepo),
+		route("GET /repos/{owner}/{repo}", f.withRepo(f.getRepo)),
+		route("PATCH /repos/{owner}/{repo}", f.withRepo(f.editRepo)),
+		route("DELETE /repos/{owner}/{repo}", f.withRepo(f.deleteRepo)),
+		route("POST /repos/{owner}/{repo}/transfer", f.withRepo(f.transferRepo)),
+		route("PUT /repos/{owner}/{repo}/topics", f.withRepo(f.replaceTopics)),
+		route("GET /repos/{owner}/{repo}/vulnerability-alerts", f.withRepo(f.getVulnerabilityAlerts)),
+		route("PUT /repos/{owner}/{repo}/vulnerability-alerts", f.withRepo(f.setVulnerabilityAlerts(true))),
+		route("DELETE /repos/{owner}/{repo}/vulnerability-alerts", f.withRepo(f.setVulnerabilityAlerts(false))),
+		route("PUT /repos/{owner}/{repo}/automated-security-fixes", f.withRepo(f.setSecurityFixes(true))),
+		route("DELETE /repos/{owner}/{repo}/automated-security-fixes", f.withRepo(f.setSecurityFixes(false))),
+		route("POST /repos/{owner}/{repo}/pages", f.withRepo(f.setPages(true))),
+		route("DELETE /repos/{owner}/{repo}/pages", f.withRepo(f.setPages(false))),
+		route("GET /repos/{owner}/{repo}/branches", f.withRepo(f.listBranches)),
+		route("GET /repos/{owner}/{repo}/branches/{branch}", f.withRepo(f.getBranch)),
+		route("POST /repos/{owner}/{repo}/git/refs", f.withRepo(f.createRef)),
+		route("GET /repos/{owner}/{repo}/branches/{branch}/protection", f.withRepo(f.getProtection)),
+		route("PUT /repos/{owner}/{repo}/branches/{branch}/protection", f.withRepo(f.updateProtection)),
+		route("DELETE /repos/{owner}/{repo}/branches/{branch}/protection", f.withRepo(f.deleteProtection)),
+		route("GET /repos/{owner}/{repo}/teams", f.withRepo(f.listRepoTeams)),
+		route("GET /repos/{owner}/{repo}/collaborators", f.withRepo(f.listCollaborators)),
+		route("PUT /repos/{owner}/{repo}/collaborators/{user}", f.withRepo(f.addCollaborator)),
+		route("DELETE /repos/{owner}/{repo}/collaborators/{user}", f.withRepo(f.removeCollaborator)),
+		route("GET /repos/{owner}/{repo}/invitations", f.withRepo(f.listInvitations)),
+		route("PATCH /repos/{owner}/{repo}/invitations/{id}", f.withRepo(f.updateInvitation)),
+		route("GET /repos/{owner}/{repo}/labels", f.withRepo(f.listLabels)),
+		route("POST /repos/{owner}/{repo}/labels", f.withRepo(f.createLabel)),
+		route("PATCH /repos/{owner}/{repo}/labels/{name}", f.withRepo(f.editLabel)),
+		route("DELETE /repos/{owner}/{repo}/labels/{name}", f.withRepo(f.deleteLabel)),
+		route("GET /repos/{owner}/{repo}/issues", f.withRepo(f.listIssues)),
+		route("POST /repos/{owner}/{repo}/issues/{number}/labels", f.withRepo(f.addIssueLabels)),
+		route("GET /repos/{owner}/{repo}/hooks", f.withRepo(f.listHooks)),
+		route("POST /repos/{owner}/{repo}/hooks", f.withRepo(f.createHook)),
+		route("GET /repos/{owner}/{repo}/hooks/{id}", f.withRepo(f.withHook(f.getHook))),
+		route("PATCH /repos/{owner}/{repo}/hooks/{id}", f.withRepo(f.withHook(f.editHook))),
+		route("DELETE /repos/{owner}/{repo}/hooks/{id}", f.withRepo(f.withHook(f.deleteHook))),
+		route("POST /repos/{owner}/{repo}/hooks/{id}/pings", f.withRepo(f.withHook(f.pingHook))),
+		route("GET /repos/{owner}/{repo}/hooks/{id}/deliveries", f.withRepo(f.withHook(f.listDeliveries))),
+		route("GET /repos/{owner}/{repo}/releases", f.withRepo(f.listReleases)),
+		route("GET /repos/{owner}/{repo}/releases/tags/{tag}", f.withRepo(f.getReleaseByTag)),
+		route("GET /repos/{owner}/{repo}/releases/assets/{id}", f.withRepo(f.downloadAsset)),
+		route("POST /repos/{owner}/{repo}/hooks/{id}/deliveries/{delivery}/attempts", f.withRepo(f.withHook(f.redeliver))),
+	}
+}
+
+func route(spec string, handle func(p map[string]string, q url.Values, body []byte) Response) fakeRoute {
+	parts := strings.SplitN(spec, " ", 2)
+	return fakeRoute{method: parts[0], pattern: strings.Split(strings.Trim(parts[1], "/"), "/"), handle: handle}
+}
+
+func (r fakeRoute) match(method string, segments []string) (map[string]string, bool) {
+	if r.method != method || len(r.pattern) != len(segments) {
+		return nil, false
+	}
+
+	params := make(map[string]string)
+	for i, part := range r.pattern {
+		segment, err := url.PathUnescape(segments[i])
+		if err != nil {
+			return nil, false
+		}
+
+		if strings.HasPrefix(part, "{") {
+			params[strings.Trim(part, "{}")] = segment
+		} else if part != segment {
+			return nil, false
+		}
+	}
+
+	return params, true
+}
+
+// withRepo resolves the {owner} and {repo} parameters
+func (f *FakeGithub) withRepo(handle func(r *FakeRepo, p map[string]string, q url.Values, body []byte) Response) func(p map[string]string, q url.Values, body []byte) Response {
+	return func(p map[string]string, q url.Values, body []byte) Response {
+		r := f.repo(p["owner"], p["repo"])
+		if r == nil {
+			return errorResponse(http.StatusNotFound, "Not Found")
+		}
+
+		return handle(r, p, q, body)
+	}
+}
+
+// withHook resolves the {id} parameter of a webhook
+func (f *FakeGithub) withHook(handle func(r *FakeRepo, hook *github.Hook, p map[string]string, body []byte) Response) func(r *FakeRepo, p map[string]string, q url.Values, body []byte) Response {
+	return func(r *FakeRepo, p map[string]string, q url.Values, body []byte) Response {
+		id, _ := strconv.ParseInt(p["id"], 10, 64)
+		for _, hook := range r.Hooks {
+			if hook.GetID() == id {
+				return handle(r, hook, p, body)
+			}
+		}
+
+		return errorResponse(http.StatusNotFound, "Not Found")
+	}
+}
+
+func (f *FakeGithub) id() int64 {
+	f.nextID++
+	return f.nextID
+}
+
+func (f *FakeGithub) org(login string) *fakeOrg {
+	return f.orgs[strings.ToLower(login)]
+}
+
+func (f *FakeGithub) repo(owner string, name string) *FakeRepo {
+	org := f.org(owner)
+	if org == nil {
+		return nil
+	}
+
+	return org.repos[strings.ToLower(name)]
+}
+
+func (f *FakeGithub) getUser(p map[string]string, q url.Values, body []byte) Response {
+	login := p["user"]
+	if login == "" {
+		login = f.Login
+	}
+
+	return jsonResponse(http.StatusOK, map[string]interface{}{"login": login, "type": "User"})
+}
+
+func (f *FakeGithub) getOrg(p map[string]string, q url.Values, body []byte) Response {
+	org := f.org(p["org"])
+	if org == nil {
+		return errorResponse(http.StatusNotFound, "Not Found")
+	}
+
+	return jsonResponse(http.StatusOK, map[string]interface{}{"id": org.id, "login": org.login})
+}
+
+func (f *FakeGithub) listTeams(p map[string]string, q url.Values, body []byte) Response {
+	org := f.org(p["org"])
+	if org == nil {
+		return errorResponse(http.StatusNotFound, "Not Found")
+	}
+
+	teams := make([]*github.Team, 0, len(org.teams))
+	for _, team := range org.teams {
+		teams = append(teams, team)
+	}
+	sort.Slice(teams, func(i, j int) bool { return teams[i].GetSlug() < teams[j].GetSlug() })
+
+	return f.page(teams)
+}
+
+func (f *FakeGithub) listTeamRepos(p map[string]string, q url.Values, body []byte) Response {
+	org := f.org(p["org"])
+	if org == nil || org.teams[p["team"]] == nil {
+		return errorResponse(http.StatusNotFound, "Not Found")
+	}
+
+	repos := []map[string]interface{}{}
+	for _, r := range org.sortedRepos() {
+		if _, ok := r.Teams[p["team"]]; ok {
+			repos = append(repos, r.Data)
+		}
+	}
+
+	return f.page(repos)
+}
+
+func (f *FakeGithub) addTeamRepo(p map[string]string, q url.Values, body []byte) Response {
+	var req github.TeamAddTeamRepoOptions
+	if err := json.Unmarshal(body, &req); err != nil {
+		return errorResponse(http.StatusBadRequest, err.Error())
+	}
+
+	for _, org := range f.orgs {
+		if strconv.FormatInt(org.id, 10) != p["orgID"] {
+			continue
+		}
+
+		r := f.repo(p["owner"], p["repo"])
+		if r == nil || !strings.EqualFold(p["owner"], org.login) {
+			return errorResponse(http.StatusNotFound, "Not Found")
+		}
+
+		for slug, team := range org.teams {
+			if strconv.FormatInt(team.GetID(), 10) == p["teamID"] {
+				r.Teams[slug] = req.Permission
+				return Response{Status: http.StatusNoContent}
+			}
+		}
+	}
+
+	return errorResponse(http.StatusNotFound, "Not Found")
+}
+
+func (f *FakeGithub) listRepos(p map[string]string, q url.Values, body []byte) Response {
+	org := f.org(p["org"])
+	if org == nil {
+		return errorResponse(http.StatusNotFound, "Not Found")
+	}
+
+	repos := []map[string]interface{}{}
+	for _, r := range org.sortedRepos() {
+		repos = append(repos, r.Data)
+	}
+
+	return f.page(repos)
+}
+
+func (f *FakeGithub) postRepo(p map[string]string, q url.Values, body []byte) Response {
+	org := f.org(p["org"])
+	if org == nil {
+		return errorResponse(http.StatusNotFound, "Not Found")
+	}
+
+	var req map[string]interface{}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return errorResponse(http.StatusBadRequest, err.Error())
+	}
+
+	r, err := f.createRepo(org, req)
+	if err != nil {
+		return errorResponse(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	return jsonResponse(http.StatusCreated, r.Data)
+}
+
+func (f *FakeGithub) createRepo(org *fakeOrg, req map[string]interface{}) (*FakeRepo, error) {
+	name, _ := req["name"].(string)
+	if name == "" {
+		return nil, fmt.Errorf("Repository creation failed: name is missing")
+	}
+	if org.repos[strings.ToLower(name)] != nil {
+		return nil, fmt.Errorf("Repository creation failed: name already exists on this account")
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	r := &FakeRepo{
+		Data: map[string]interface{}{
+			"id":                     f.id(),
+			"private":                false,
+			"visibility":             "public",
+			"description":            "",
+			"homepage":               "",
+			"has_issues":             true,
+			"has_projects":           true,
+			"has_wiki":               true,
+			"has_pages":              false,
+			"archived":               false,
+			"is_template":            false,
+			"default_branch":         "main",
+			"topics":                 []string{},
+			"allow_merge_commit":     true,
+			"allow_squash_merge":     true,
+			"allow_rebase_merge":     true,
+			"allow_auto_merge":       false,
+			"delete_branch_on_merge": false,
+			"created_at":             now,
+			"pushed_at":              now,
+		},
+		Collaborators: make(map[string]string),
+		Teams:         make(map[string]string),
+		Deliveries:    make(map[int64][]map[string]interface{}),
+		Protections:   make(map[string]*github.Protection),
+		git:           memory.NewStorage(),
+	}
+
+	for key, value := range req {
+		switch key {
+		case "auto_init", "gitignore_template", "license_template", "team_id":
+		default:
+			r.Data[key] = value
+		}
+	}
+	r.setVisibility(req)
+	f.place(org, r, name)
+
+	if autoInit, _ := req["auto_init"].(bool); autoInit {
+		if err := initRepository(r.git, name, r.defaultBranch()); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// place puts a repository in an organization under a name, updating its urls
+func (f *FakeGithub) place(org *fakeOrg, r *FakeRepo, name string) {
+	fullName := org.login + "/" + name
+	host := strings.TrimPrefix(strings.TrimPrefix(f.URL, "http://"), "https://")
+
+	r.Data["name"] = name
+	r.Data["full_name"] = fullName
+	r.Data["owner"] = map[string]interface{}{"login": org.login, "id": org.id, "type": "Organization"}
+	r.Data["html_url"] = f.URL + "/" + fullName
+	r.Data["clone_url"] = f.URL + "/" + fullName + ".git"
+	r.Data["git_url"] = "git://" + host + "/" + fullName + ".git"
+	r.Data["ssh_url"] = "git@" + host + ":" + fullName + ".git"
+	r.Data["url"] = f.URL + "/repos/" + fullName
+
+	org.repos[strings.ToLower(name)] = r
+}
+
+func (f *FakeGithub) getRepo(r *FakeRepo, p map[string]string, q url.Values, body []byte) Response {
+	return jsonResponse(http.StatusOK, r.Data)
+}
+
+func (f *FakeGithub) editRepo(r *FakeRepo, p map[string]string, q url.Values, body []byte) Response {
+	var req map[string]interface{}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return errorResponse(http.StatusBadRequest, err.Error())
+	}
+
+	if branch, ok := req["default_branch"].(string); ok && !r.hasBranch(branch) {
+		return errorResponse(http.StatusUnprocessableEntity, "Validation Failed: default_branch does not exist")
+	}
+
+	org := f.org(p["owner"])
+	if name, ok := req["name"].(string); ok && !strings.EqualFold(name, r.name()) {
+		if org.repos[strings.ToLower(name)] != nil {
+			return errorResponse(http.StatusUnprocessableEntity, "name already exists on this account")
+		}
+
+		delete(org.repos, strings.ToLower(r.name()))
+		f.place(org, r, name)
+	}
+
+	for key, value := range req {
+		if key == "security_and_analysis" {
+			mergeMaps(r.Data, map[string]interface{}{key: value})
+			continue
+		}
+		if key != "name" {
+			r.Data[key] = value
+		}
+	}
+	r.setVisibility(req)
+
+	if branch, ok := req["default_branch"].(string); ok {
+		r.git.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, plumbing.NewBranchReferenceName(branch)))
+	}
+
+	return jsonResponse(http.StatusOK, r.Data)
+}
+
+func (f *FakeGithub) deleteRepo(r *FakeRepo, p map[string]string, q url.Values, body []byte) Response {
+	delete(f.org(p["owner"]).repos, strings.ToLower(r.name()))
+	return Response{Status: http.StatusNoContent}
+}
+
+func (f *FakeGithub) transferRepo(r *FakeRepo, p map[string]string, q url.Values, body []byte) Response {
+	var req github.TransferRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return errorResponse(http.StatusBadRequest, err.Error())
+	}
+
+	newOrg := f.org(req.NewOwner)
+	if newOrg == nil {
+		return errorResponse(http.StatusUnprocessableEntity, "new_owner does not exist")
+	}
+	if newOrg.repos[strings.ToLower(r.name())] != nil {
+		return errorResponse(http.StatusUnprocessableEntity, "repository already exists in new_owner")
+	}
+
+	delete(f.org(p["owner"]).repos, strings.ToLower(r.name()))
+	// teams belong to the old organization
+	r.Teams = make(map[string]string)
+	f.place(newOrg, r, r.name())
+
+	return jsonResponse(http.StatusAccepted, r.Data)
+}
+
+func (f *FakeGithub) replaceTopics(r *FakeRepo, p map[string]string, q url.Values, body []byte) Response {
+	var req struct {
+		Names []string `json:"names"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return errorResponse(http.StatusBadRequest, err.Error())
+	}
+	if req.Names == nil {
+		req.Names = []string{}
+	}
+
+	r.Data["topics"] = req.Names
+
+	return jsonResponse(http.StatusOK, req)
+}
+
+func (f *FakeGithub) getVulnerabilityAlerts(r *FakeRepo, p map[string]string, q url.Values, body []byte) Response {
+	if !r.VulnerabilityAlerts {
+		return errorResponse(http.StatusNotFound, "Not Found")
+	}
+
+	return Response{Status: http.StatusNoContent}
+}
+
+func (f *FakeGithub) setVulnerabilityAlerts(enabled bool) func(r *FakeRepo, p map[string]string, q url.Values, body []byte) Response {
+	return func(r *FakeRepo, p map[string]string, q url.Values, body []byte) Response {
+		r.VulnerabilityAlerts = enabled
+		if !enabled {
+			r.SecurityFixes = false
+		}
+
+		return Response{Status: http.StatusNoContent}
+	}
+}
+
+func (f *FakeGithub) setSecurityFixes(enabled bool) func(r *FakeRepo, p map[string]string, q url.Values, body []byte) Response {
+	return func(r *FakeRepo, p map[string]string, q url.Values, body []byte) Response {
+		if enabled && !r.VulnerabilityAlerts {
+			return errorResponse(http.StatusUnprocessableEntity, "Vulnerability alerts must be enabled")
+		}
+		r.SecurityFixes = enabled
+
+		return Response{Status: http.StatusNoContent}
+	}
+}
+
+func (f *FakeGithub) setPages(enabled bool) func(r *FakeRepo, p map[string]string, q url.Values, body []byte) Response {
+	return func(r *FakeRepo, p map[string]string, q url.Values, body []byte) Response {
+		if r.Pages == enabled {
+			if enabled {
+				return errorResponse(http.StatusConflict, "GitHub Pages is already enabled")
+			}
+			return errorResponse(http.StatusNotFound, "Not Found")
+		}
+
+		r.Pages = enabled
+		r.Data["has_pages"] = enabled
+		if !enabled {
+			return Response{Status: http.StatusNoContent}
+		}
+
+		return jsonResponse(http.StatusCreated, map[string]interface{}{"status": "built"})
+	}
+}
+
+func (f *FakeGithub) listBranches(r *FakeRepo, p map[string]string, q url.Values, body []byte) Response {
+	branches := []map[string]interface{}{}
+	for _, branch := range r.branches() {
+		protected := r.Protections[branch.Name().Short()] != nil
+		if q.Get("protected") == "true" && !protected {
+			continue
+		}
+
+		branches = append(branches, branchJSON(branch, protected))
+	}
+
+	return f.page(branches)
+}
+
+func (f *FakeGithub) getBranch(r *FakeRepo, p map[string]string, q url.Values, body []byte) Response {
+	for _, branch := range r.branches() {
+		if branch.Name().Short() == p["branch"] {
+			return jsonResponse(http.StatusOK, branchJSON(branch, r.Protections[p["branch"]] != nil))
+		}
+	}
+
+	return errorResponse(http.StatusNotFound, "Branch not found")
+}
+
+func (f *FakeGithub) createRef(r *FakeRepo, p map[string]string, q url.Values, body []byte) Response {
+	var req struct {
+		Ref string `json:"ref"`
+		SHA string `json:"sha"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return errorResponse(http.StatusBadRequest, err.Error())
+	}
+
+	name := plumbing.ReferenceName(req.Ref)
+	if !name.IsBranch() {
+		return errorResponse(http.StatusUnprocessableEntity, "Reference name must be a branch")
+	}
+	if r.hasBranch(name.Short()) {
+		return errorResponse(http.StatusUnprocessableEntity, "Reference already exists")
+	}
+	if _, err := r.git.EncodedObject(plumbing.AnyObject, plumbing.NewHash(req.SHA)); err != nil {
+		return errorResponse(http.StatusUnprocessableEntity, "Object does not exist")
+	}
+
+	r.git.SetReference(plumbing.NewHashReference(name, plumbing.NewHash(req.SHA)))
+
+	return jsonResponse(http.StatusCreated, map[string]interface{}{
+		"ref":    req.Ref,
+		"object": map[string]interface{}{"sha": req.SHA, "type": "commit"},
+	})
+}
+
+func (f *FakeGithub) getProtection(r *FakeRepo, p map[string]string, q url.Values, body []byte) Response {
+	protection, ok := r.Protections[p["branch"]]
+	if !ok {
+		return errorResponse(http.StatusNotFound, "Branch not protected")
+	}
+
+	return jsonResponse(http.StatusOK, protection)
+}
+
+func (f *FakeGithub) updateProtection(r *FakeRepo, p map[string]string, q url.Values, body []byte) Response {
+	if !r.hasBranch(p["branch"]) {
+		return errorResponse(http.StatusNotFound, "Branch not found")
+	}
+
+	var req github.ProtectionRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return errorResponse(http.StatusBadRequest, err.Error())
+	}
+
+	protection := &github.Protection{
+		RequiredStatusChecks: req.RequiredStatusChecks,
+		EnforceAdmins:        &github.AdminEnforcement{Enabled: req.EnforceAdmins},
+	}
+	if reviews := req.RequiredPullRequestReviews; reviews != nil {
+		protection.RequiredPullRequestReviews = &github.PullRequestReviewsEnforcement{
+			DismissStaleReviews:          reviews.DismissStaleReviews,
+			RequireCodeOwnerReviews:      reviews.RequireCodeOwnerReviews,
+			RequiredApprovingReviewCount: reviews.RequiredApprovingReviewCount,
+		}
+	}
+	r.Protections[p["branch"]] = protection
+
+	return jsonResponse(http.StatusOK, protection)
+}
+
+func (f *FakeGithub) deleteProtection(r *FakeRepo, p map[string]string, q url.Values, body []byte) Response {
+	delete(r.Protections, p["branch"])
+	return Response{Status: http.StatusNoContent}
+}
+
+func (f *FakeGithub) listRepoTeams(r *FakeRepo, p map[string]string, q url.Values, body []byte) Response {
+	org := f.org(p["owner"])
+
+	teams := []*github.Team{}
+	for slug, permission := range r.Teams {
+		team := *org.teams[slug]
+		team.Permission = github.String(permission)
+		teams = append(teams, &team)
+	}
+	sort.Slice(teams, func(i, j int) bool { return teams[i].GetSlug() < teams[j].GetSlug() })
+
+	return f.page(teams)
+}
+
+func (f *FakeGithub) listCollaborators(r *FakeRepo, p map[string]string, q url.Values, body []byte) Response {
+	org := f.org(p["owner"])
+
+	var logins []string
+	for login := range r.Collaborators {
+		if q.Get("affiliation") == "outside" && org.members[login] {
+			continue
+		}
+		logins = append(logins, login)
+	}
+	sort.Strings(logins)
+
+	users := []map[string]interface{}{}
+	for _, login := range logins {
+		users = append(users, map[string]interface{}{"login": login, "permissions": permissions(r.Collaborators[login])})
+	}
+
+	return f.page(users)
+}
+
+func (f *FakeGithub) addCollaborator(r *FakeRepo, p map[string]string, q url.Values, body []byte) Response {
+	var req github.RepositoryAddCollaboratorOptions
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &req); err != nil {
+			return errorResponse(http.StatusBadRequest, err.Error())
+		}
+	}
+	if req.Permission == "" {
+		req.Permission = "push"
+	}
+
+	login := strings.ToLower(p["user"])
+	if _, ok := r.Collaborators[login]; ok || f.org(p["owner"]).members[login] {
+		r.Collaborators[login] = req.Permission
+		return Response{Status: http.StatusNoContent}
+	}
+
+	invitation := &github.RepositoryInvitation{
+		ID:          github.Int64(f.id()),
+		Invitee:     &github.User{Login: github.String(p["user"])},
+		Inviter:     &github.User{Login: github.String(f.Login)},
+		Permissions: github.String(invitationPermission(req.Permission)),
+	}
+	r.Invitations = append(r.Invitations, invitation)
+
+	return jsonResponse(http.StatusCreated, invitation)
+}
+
+func (f *FakeGithub) removeCollaborator(r *FakeRepo, p map[string]string, q url.Values, body []byte) Response {
+	delete(r.Collaborators, strings.ToLower(p["user"]))
+	return Response{Status: http.StatusNoContent}
+}
+
+func (f *FakeGithub) listInvitations(r *FakeRepo, p map[string]string, q url.Values, body []byte) Response {
+	invitations := r.Invitations
+	if invitations == nil {
+		invitations = []*github.RepositoryInvitation{}
+	}
+
+	return f.page(invitations)
+}
+
+func (f *FakeGithub) updateInvitation(r *FakeRepo, p map[string]string, q url.Values, body []byte) Response {
+	var req struct {
+		Permissions string `json:"permissions"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return errorResponse(http.StatusBadRequest, err.Error())
+	}
+
+	for _, invitation := range r.Invitations {
+		if strconv.FormatInt(invitation.GetID(), 10) == p["id"] {
+			invitation.Permissions = github.String(req.Permissions)
+			return jsonResponse(http.StatusOK, invitation)
+		}
+	}
+
+	return errorResponse(http.StatusNotFound, "Not Found")
+}
+
+func (f *FakeGithub) listLabels(r *FakeRepo, p map[string]string, q url.Values, body []byte) Response {
+	labels := r.Labels
+	if labels == nil {
+		labels = []*github.Label{}
+	}
+
+	return f.page(labels)
+}
+
+func (f *FakeGithub) createLabel(r *FakeRepo, p map[string]string, q url.Values, body []byte) Response {
+	var label github.Label
+	if err := json.Unmarshal(body, &label); err != nil {
+		return errorResponse(http.StatusBadRequest, err.Error())
+	}
+	if r.label(label.GetName()) >= 0 {
+		return errorResponse(http.StatusUnprocessableEntity, "Validation Failed: already_exists")
+	}
+
+	label.ID = github.Int64(f.id())
+	r.Labels = append(r.Labels, &label)
+
+	return jsonResponse(http.StatusCreated, label)
+}
+
+func (f *FakeGithub) editLabel(r *FakeRepo, p map[string]string, q url.Values, body []byte) Response {
+	i := r.label(p["name"])
+	if i < 0 {
+		return errorResponse(http.StatusNotFound, "Not Found")
+	}
+
+	var edit github.Label
+	if err := json.Unmarshal(body, &edit); err != nil {
+		return errorResponse(http.StatusBadRequest, err.Error())
+	}
+
+	label := r.Labels[i]
+	if edit.Name != nil {
+		if other := r.label(edit.GetName()); other >= 0 && other != i {
+			return errorResponse(http.StatusUnprocessableEntity, "Validation Failed: already_exists")
+		}
+		r.renameIssueLabel(label.GetName(), edit.GetName())
+		label.Name = edit.Name
+	}
+	if edit.Color != nil {
+		label.Color = edit.Color
+	}
+	if edit.Description != nil {
+		label.Description = edit.Description
+	}
+
+	return jsonResponse(http.StatusOK, label)
+}
+
+func (f *FakeGithub) deleteLabel(r *FakeRepo, p map[string]string, q url.Values, body []byte) Response {
+	i := r.label(p["name"])
+	if i < 0 {
+		return errorResponse(http.StatusNotFound, "Not Found")
+	}
+
+	r.renameIssueLabel(r.Labels[i].GetName(), "")
+	r.Labels = append(r.Labels[:i], r.Labels[i+1:]...)
+
+	return Response{Status: http.StatusNoContent}
+}
+
+func (f *FakeGithub) listIssues(r *FakeRepo, p map[string]string, q url.Values, body []byte) Response {
+	var wanted []string
+	if labels := q.Get("labels"); labels != "" {
+		wanted = strings.Split(labels, ",")
+	}
+
+	numbers := make([]int, 0, len(r.Issues))
+	for number := range r.Issues {
+		numbers = append(numbers, number)
+	}
+	sort.Ints(numbers)
+
+	issues := []map[string]interface{}{}
+	for _, number := range numbers {
+		if !hasAll(r.Issues[number], wanted) {
+			continue
+		}
+
+		labels := []map[string]string{}
+		for _, name := range r.Issues[number] {
+			labels = append(labels, map[string]string{"name": name})
+		}
+		issues = append(issues, map[string]interface{}{"number": number, "labels": labels})
+	}
+
+	return f.page(issues)
+}
+
+func (f *FakeGithub) addIssueLabels(r *FakeRepo, p map[string]string, q url.Values, body []byte) Response {
+	number, err := strconv.Atoi(p["number"])
+	if _, ok := r.Issues[number]; err != nil || !ok {
+		return errorResponse(http.StatusNotFound, "Not Found")
+	}
+
+	var names []string
+	if err := json.Unmarshal(body, &names); err != nil {
+		return errorResponse(http.StatusBadRequest, err.Error())
+	}
+
+	var labels []*github.Label
+	for _, name := range names {
+		i := r.label(name)
+		if i < 0 {
+			// github creates the missing labels
+			r.Labels = append(r.Labels, &github.Label{ID: github.Int64(f.id()), Name: github.String(name), Color: github.String("ededed")})
+			i = len(r.Labels) - 1
+		}
+		if !hasAll(r.Issues[number], []string{name}) {
+			r.Issues[number] = append(r.Issues[number], r.Labels[i].GetName())
+		}
+	}
+	for _, name := range r.Issues[number] {
+		labels = append(labels, r.Labels[r.label(name)])
+	}
+
+	return jsonResponse(http.StatusOK, labels)
+}
+
+func (f *FakeGithub) listHooks(r *FakeRepo, p map[string]string, q url.Values, body []byte) Response {
+	hooks := r.Hooks
+	if hooks == nil {
+		hooks = []*github.Hook{}
+	}
+
+	return f.page(hooks)
+}
+
+func (f *FakeGithub) createHook(r *FakeRepo, p map[string]string, q url.Values, body []byte) Response {
+	var hook github.Hook
+	if err := json.Unmarshal(body, &hook); err != nil {
+		return errorResponse(http.StatusBadRequest, err.Error())
+	}
+
+	for _, existing := range r.Hooks {
+		if existing.Config["url"] == hook.Config["url"] {
+			return errorResponse(http.StatusUnprocessableEntity, "Validation Failed: Hook already exists on this repository")
+		}
+	}
+
+	hook.ID = github.Int64(f.id())
+	if hook.Events == nil {
+		hook.Events = []string{"push"}
+	}
+	if hook.Active == nil {
+		hook.Active = github.Bool(true)
+	}
+	r.Hooks = append(r.Hooks, &hook)
+
+	return jsonResponse(http.StatusCreated, hook)
+}
+
+func (f *FakeGithub) getHook(r *FakeRepo, hook *github.Hook, p map[string]string, body []byte) Response {
+	return jsonResponse(http.StatusOK, hook)
+}
+
+func (f *FakeGithub) editHook(r *FakeRepo, hook *github.Hook, p map[string]string, body []byte) Response {
+	var edit github.Hook
+	if err := json.Unmarshal(body, &edit); err != nil {
+		return errorResponse(http.StatusBadRequest, err.Error())
+	}
+
+	if edit.Config != nil {
+		hook.Config = edit.Config
+	}
+	if edit.Events != nil {
+		hook.Events = edit.Events
+	}
+	if edit.Active != nil {
+		hook.Active = edit.Active
+	}
+
+	return jsonResponse(http.StatusOK, hook)
+}
+
+func (f *FakeGithub) deleteHook(r *FakeRepo, hook *github.Hook, p map[string]string, body []byte) Response {
+	for i, existing := range r.Hooks {
+		if existing == hook {
+			r.Hooks = append(r.Hooks[:i], r.Hooks[i+1:]...)
+			break
+		}
+	}
+
+	return Response{Status: http.StatusNoContent}
+}
+
+func (f *FakeGithub) pingHook(r *FakeRepo, hook *github.Hook, p map[string]string, body []byte) Response {
+	r.deliver(f.id(), hook.GetID(), http.StatusOK, "ping", false)
+	return Response{Status: http.StatusNoContent}
+}
+
+func (f *FakeGithub) listDeliveries(r *FakeRepo, hook *github.Hook, p map[string]string, body []byte) Response {
+	deliveries := []map[string]interface{}{}
+	// newest first
+	for i := len(r.Deliveries[hook.GetID()]) - 1; i >= 0; i-- {
+		deliveries = append(deliveries, r.Deliveries[hook.GetID()][i])
+	}
+
+	return f.page(deliveries)
+}
+
+func (f *FakeGithub) redeliver(r *FakeRepo, hook *github.Hook, p map[string]string, body []byte) Response {
+	for _, delivery := range r.Deliveries[hook.GetID()] {
+		if fmt.Sprint(delivery["id"]) == p["delivery"] {
+			r.deliver(f.id(), hook.GetID(), http.StatusOK, delivery["event"].(string), true)
+			return jsonResponse(http.StatusAccepted, map[string]interface{}{})
+		}
+	}
+
+	return errorResponse(http.StatusNotFound, "Not Found")
+}
+
+func (f *FakeGithub) listReleases(r *FakeRepo, p map[string]string, q url.Values, body []byte) Response {
+	releases := r.Releases
+	if releases == nil {
+		releases = []*github.RepositoryRelease{}
+	}
+
+	return f.page(releases)
+}
+
+func (f *FakeGithub) getReleaseByTag(r *FakeRepo, p map[string]string, q url.Values, body []byte) Response {
+	for _, release := range r.Releases {
+		if release.GetTagName() == p["tag"] {
+			return jsonResponse(http.StatusOK, release)
+		}
+	}
+
+	return errorResponse(http.StatusNotFound, "Not Found")
+}
+
+func (f *FakeGithub) downloadAsset(r *FakeRepo, p map[string]string, q url.Values, body []byte) Response {
+	id, _ := strconv.ParseInt(p["id"], 10, 64)
+	content, ok := r.Assets[id]
+	if !ok {
+		return errorResponse(http.StatusNotFound, "Not Found")
+	}
+
+	return Response{
+		Status: http.StatusOK,
+		Header: http.Header{"Content-Type": []string{"application/octet-stream"}},
+		Body:   content,
+	}
+}
+
+// page serves the page of items asked by the page and per_page parameters of the current request, 30 items
+// by default and at most 100 or PerPage. Like github, the Link header points to the other pages.
+func (f *FakeGithub) page(items interface{}) Response {
+	q := f.current.Query()
+
+	perPage, err := strconv.Atoi(q.Get("per_page"))
+	if err != nil || perPage <= 0 {
+		perPage = 30
+	}
+	if perPage > 100 {
+		perPage = 100
+	}
+	if f.PerPage > 0 && perPage > f.PerPage {
+		perPage = f.PerPage
+	}
+
+	page, err := strconv.Atoi(q.Get("page"))
+	if err != nil || page <= 0 {
+		page = 1
+	}
+
+	list := reflect.ValueOf(items)
+	last := (list.Len() + perPage - 1) / perPage
+	if last == 0 {
+		last = 1
+	}
+
+	start, end := (page-1)*perPage, page*perPage
+	if start > list.Len() {
+		start = list.Len()
+	}
+	if end > list.Len() {
+		end = list.Len()
+	}
+
+	resp := jsonResponse(http.StatusOK, list.Slice(start, end).Interface())
+
+	link := func(page int, rel string) string {
+		q.Set("page", strconv.Itoa(page))
+		q.Set("per_page", strconv.Itoa(perPage))
+		return fmt.Sprintf(`<%s%s?%s>; rel="%s"`, f.URL, f.current.Path, q.Encode(), rel)
+	}
+
+	var links []string
+	if page < last {
+		links = append(links, link(page+1, "next"), link(last, "last"))
+	}
+	if page > 1 {
+		links = append(links, link(1, "first"), link(page-1, "prev"))
+	}
+	if len(links) > 0 {
+		resp.Header.Set("Link", strings.Join(links, ", "))
+	}
+
+	return resp
+}
+
+func (r *FakeRepo) deliver(id int64, hookID int64, statusCode int, event string, redelivery bool) {
+	status := "OK"
+	if statusCode < 200 || statusCode >= 300 {
+		status = "Invalid HTTP Response: " + strconv.Itoa(statusCode)
+	}
+
+	r.Deliveries[hookID] = append(r.Deliveries[hookID], map[string]interface{}{
+		"id":           id,
+		"guid":         fmt.Sprintf("%08x-fake", id),
+		"delivered_at": time.Now().UTC().Format(time.RFC3339),
+		"redelivery":   redelivery,
+		"duration":     0.1,
+		"status":       status,
+		"status_code":  statusCode,
+		"event":        event,
+	})
+}
+
+func (r *FakeRepo) name() string {
+	name, _ := r.Data["name"].(string)
+	return name
+}
+
+func (r *FakeRepo) defaultBranch() string {
+	branch, _ := r.Data["default_branch"].(string)
+	return branch
+}
+
+// setVisibility keeps private and visibility consistent, visibility wins
+func (r *FakeRepo) setVisibility(req map[string]interface{}) {
+	if visibility, ok := req["visibility"].(string); ok {
+		r.Data["private"] = visibility != "public"
+		return
+	}
+
+	if private, ok := req["private"].(bool); ok {
+		r.Data["visibility"] = "public"
+		if private {
+			r.Data["visibility"] = "private"
+		}
+	}
+}
+
+func (r *FakeRepo) branches() []*plumbing.Reference {
+	var branches []*plumbing.Reference
+
+	refs, err := r.git.IterReferences()
+	if err != nil {
+		return nil
+	}
+	refs.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Name().IsBranch() {
+			branches = append(branches, ref)
+		}
+		return nil
+	})
+	sort.Slice(branches, func(i, j int) bool { return branches[i].Name() < branches[j].Name() })
+
+	return branches
+}
+
+func (r *FakeRepo) hasBranch(name string) bool {
+	_, err := r.git.Reference(plumbing.NewBranchReferenceName(name))
+	return err == nil
+}
+
+// renameIssueLabel renames a label on the issues, an empty name removes it
+func (r *FakeRepo) renameIssueLabel(old string, name string) {
+	for number, labels := range r.Issues {
+		var renamed []string
+		for _, label := range labels {
+			switch {
+			case !strings.EqualFold(label, old):
+				renamed = append(renamed, label)
+			case name != "":
+				renamed = append(renamed, name)
+			}
+		}
+		r.Issues[number] = renamed
+	}
+}
+
+func hasAll(labels []string, wanted []string) bool {
+	for _, w := range wanted {
+		found := false
+		for _, label := range labels {
+			found = found || strings.EqualFold(label, w)
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r *FakeRepo) label(name string) int {
+	for i, label := range r.Labels {
+		if strings.EqualFold(label.GetName(), name) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (o *fakeOrg) sortedRepos() []*FakeRepo {
+	repos := make([]*FakeRepo, 0, len(o.repos))
+	for _, r := range o.repos {
+		repos = append(repos, r)
+	}
+	sort.Slice(repos, func(i, j int) bool { return repos[i].name() < repos[j].name() })
+
+	return repos
+}
+
+func branchJSON(branch *plumbing.Reference, protected bool) map[string]interface{} {
+	return map[string]interface{}{
+		"name":      branch.Name().Short(),
+		"commit":    map[string]interface{}{"sha": branch.Hash().String()},
+		"protected": protected,
+	}
+}
+
+// permissions returns the permissions a user with the given permission has, as github reports them
+func permissions(permission string) map[string]bool {
+	levels := []string{"pull", "triage", "push", "maintain", "admin"}
+
+	result := make(map[string]bool, len(levels))
+	granted := true
+	for _, level := range levels {
+		result[level] = granted
+		if level == permission {
+			granted = false
+		}
+	}
+
+	return result
+}
+
+func invitationPermission(permission string) string {
+	switch permission {
+	case "pull":
+		return "read"
+	case "push":
+		return "write"
+	}
+
+	return permission
+}
+
+func mergeMaps(dst map[string]interface{}, src map[string]interface{}) {
+	for key, value := range src {
+		srcMap, srcOK := value.(map[string]interface{})
+		dstMap, dstOK := dst[key].(map[string]interface{})
+		if srcOK && dstOK {
+			mergeMaps(dstMap, srcMap)
+			continue
+		}
+
+		dst[key] = value
+	}
+}
+
+func jsonResponse(status int, v interface{}) Response {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return errorResponse(http.StatusInternalServerError, err.Error())
+	}
+
+	return Response{
+		Status: status,
+		Header: http.Header{"Content-Type": []string{"application/json; charset=utf-8"}},
+		Body:   body,
+	}
+}
+
+func errorResponse(status int, message string) Response {
+	return jsonResponse(status, map[string]string{"message": message})
+}